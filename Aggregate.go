@@ -0,0 +1,77 @@
+package bitcoin
+
+import (
+	"errors"
+	"math/big"
+)
+
+// SumAmounts returns the sum of amounts, or ErrOverflow if the sum
+// does not fit in an Amount. Intermediate sums may overflow as long
+// as the final sum fits, so the order of amounts does not matter.
+func SumAmounts(amounts []Amount) (Amount, error) {
+	sum := sumBig(amounts)
+
+	if !sum.IsInt64() {
+		return 0, ErrOverflow
+	}
+
+	return Amount(sum.Int64()), nil
+}
+
+// MinAmount returns the smallest of amounts, or 0 if amounts is
+// empty.
+func MinAmount(amounts []Amount) Amount {
+	if len(amounts) == 0 {
+		return 0
+	}
+
+	min := amounts[0]
+	for _, a := range amounts[1:] {
+		if a < min {
+			min = a
+		}
+	}
+
+	return min
+}
+
+// MaxAmount returns the largest of amounts, or 0 if amounts is
+// empty.
+func MaxAmount(amounts []Amount) Amount {
+	if len(amounts) == 0 {
+		return 0
+	}
+
+	max := amounts[0]
+	for _, a := range amounts[1:] {
+		if a > max {
+			max = a
+		}
+	}
+
+	return max
+}
+
+// AverageAmount returns the mean of amounts rounded to a satoshi
+// using mode. The sum is never overflowed. An error is returned if
+// amounts is empty.
+func AverageAmount(amounts []Amount, mode RoundingMode) (Amount, error) {
+	if len(amounts) == 0 {
+		return 0, errors.New("average of no amounts")
+	}
+
+	avg := bigDivRound(sumBig(amounts), big.NewInt(int64(len(amounts))), mode)
+
+	return Amount(avg.Int64()), nil
+}
+
+func sumBig(amounts []Amount) *big.Int {
+	sum := new(big.Int)
+	v := new(big.Int)
+
+	for _, a := range amounts {
+		sum.Add(sum, v.SetInt64(int64(a)))
+	}
+
+	return sum
+}
@@ -0,0 +1,79 @@
+package bitcoin
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSumAmounts(t *testing.T) {
+	cases := []struct {
+		in       []Amount
+		expected Amount
+		err      error
+	}{
+		{nil, 0, nil},
+		{[]Amount{BTC}, BTC, nil},
+		{[]Amount{BTC, MilliBTC, -Satoshi}, BTC + MilliBTC - Satoshi, nil},
+		{[]Amount{math.MaxInt64, 1}, 0, ErrOverflow},
+		{[]Amount{math.MinInt64, -1}, 0, ErrOverflow},
+		{[]Amount{math.MaxInt64, 1, -1}, math.MaxInt64, nil},
+		{[]Amount{math.MaxInt64, math.MaxInt64, math.MinInt64, math.MinInt64}, -2, nil},
+		{[]Amount{AllBTC, AllBTC, AllBTC}, 3 * AllBTC, nil},
+	}
+
+	for _, c := range cases {
+		result, err := SumAmounts(c.in)
+
+		if result != c.expected || err != c.err {
+			t.Errorf("SumAmounts(%v) -> %d, %v, %d, %v expected", c.in, result, err, c.expected, c.err)
+		}
+	}
+}
+
+func TestMinMaxAmount(t *testing.T) {
+	cases := []struct {
+		in  []Amount
+		min Amount
+		max Amount
+	}{
+		{nil, 0, 0},
+		{[]Amount{BTC}, BTC, BTC},
+		{[]Amount{BTC, -Satoshi, MilliBTC}, -Satoshi, BTC},
+		{[]Amount{math.MaxInt64, math.MinInt64}, math.MinInt64, math.MaxInt64},
+	}
+
+	for _, c := range cases {
+		min := MinAmount(c.in)
+		max := MaxAmount(c.in)
+
+		if min != c.min || max != c.max {
+			t.Errorf("MinAmount/MaxAmount(%v) -> %d, %d, %d, %d expected", c.in, min, max, c.min, c.max)
+		}
+	}
+}
+
+func TestAverageAmount(t *testing.T) {
+	cases := []struct {
+		in       []Amount
+		mode     RoundingMode
+		expected Amount
+		err      bool
+	}{
+		{nil, RoundHalfUp, 0, true},
+		{[]Amount{BTC}, RoundHalfUp, BTC, false},
+		{[]Amount{1, 2}, RoundHalfUp, 2, false},
+		{[]Amount{1, 2}, RoundFloor, 1, false},
+		{[]Amount{1, 2, 4}, RoundHalfUp, 2, false},
+		{[]Amount{1, 2, 4}, RoundCeil, 3, false},
+		{[]Amount{math.MaxInt64, math.MaxInt64}, RoundHalfUp, math.MaxInt64, false},
+		{[]Amount{math.MinInt64, math.MinInt64}, RoundHalfUp, math.MinInt64, false},
+	}
+
+	for _, c := range cases {
+		result, err := AverageAmount(c.in, c.mode)
+
+		if result != c.expected || (err != nil) != c.err {
+			t.Errorf("AverageAmount(%v, %d) -> %d, %v, %d expected", c.in, c.mode, result, err, c.expected)
+		}
+	}
+}
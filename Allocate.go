@@ -0,0 +1,85 @@
+package bitcoin
+
+import (
+	"math/big"
+	"sort"
+)
+
+// Split divides a into n parts that differ by at most one satoshi
+// and always sum to a. Leftover satoshis go to the first parts, so
+// 10 satoshis split in 3 becomes 4, 3 and 3. Split returns nil if n
+// is not positive.
+func (a Amount) Split(n int) []Amount {
+	if n <= 0 {
+		return nil
+	}
+
+	ratios := make([]int, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+
+	return a.Allocate(ratios)
+}
+
+// Allocate divides a into parts proportional to ratios. The parts
+// always sum to a. Satoshis that cannot be divided exactly go to the
+// parts with the largest remainders, and to the earliest of those on
+// ties, so the result is deterministic. Allocate returns nil if a
+// ratio is negative or all ratios are zero.
+func (a Amount) Allocate(ratios []int) []Amount {
+	// The ratios can sum past math.MaxInt64, and the magnitude of
+	// math.MinInt64 does not fit in an int64, so use big integers
+	// throughout.
+	total := new(big.Int)
+
+	for _, r := range ratios {
+		if r < 0 {
+			return nil
+		}
+
+		total.Add(total, big.NewInt(int64(r)))
+	}
+
+	if total.Sign() == 0 {
+		return nil
+	}
+
+	// Allocate the magnitude, and restore the sign at the end.
+	magnitude := new(big.Int).Abs(big.NewInt(int64(a)))
+
+	parts := make([]*big.Int, len(ratios))
+	remainders := make([]*big.Int, len(ratios))
+	left := new(big.Int).Set(magnitude)
+
+	for i, r := range ratios {
+		num := new(big.Int).Mul(magnitude, big.NewInt(int64(r)))
+		parts[i], remainders[i] = new(big.Int).QuoRem(num, total, new(big.Int))
+		left.Sub(left, parts[i])
+	}
+
+	order := make([]int, len(ratios))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]].Cmp(remainders[order[j]]) > 0
+	})
+
+	// left is smaller than the number of parts.
+	for i := 0; i < len(parts) && left.Cmp(big.NewInt(int64(i))) > 0; i++ {
+		parts[order[i]].Add(parts[order[i]], big.NewInt(1))
+	}
+
+	result := make([]Amount, len(parts))
+	for i, p := range parts {
+		if a < 0 {
+			p.Neg(p)
+		}
+
+		result[i] = Amount(p.Int64())
+	}
+
+	return result
+}
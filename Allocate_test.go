@@ -0,0 +1,84 @@
+package bitcoin
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSplitAmount(t *testing.T) {
+	cases := []struct {
+		in       Amount
+		n        int
+		expected []Amount
+	}{
+		{10, 3, []Amount{4, 3, 3}},
+		{11, 3, []Amount{4, 4, 3}},
+		{12, 3, []Amount{4, 4, 4}},
+		{-10, 3, []Amount{-4, -3, -3}},
+		{2, 3, []Amount{1, 1, 0}},
+		{0, 2, []Amount{0, 0}},
+		{BTC, 1, []Amount{BTC}},
+		{BTC, 0, nil},
+		{BTC, -1, nil},
+		{math.MaxInt64, 2, []Amount{math.MaxInt64/2 + 1, math.MaxInt64 / 2}},
+		{math.MinInt64, 3, []Amount{math.MinInt64/3 - 1, math.MinInt64/3 - 1, math.MinInt64 / 3}},
+	}
+
+	for _, c := range cases {
+		result := c.in.Split(c.n)
+
+		if !reflect.DeepEqual(result, c.expected) {
+			t.Errorf("%d.Split(%d) -> %v, %v expected", c.in, c.n, result, c.expected)
+		}
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	maxRatio := int(^uint(0) >> 1)
+
+	cases := []struct {
+		in       Amount
+		ratios   []int
+		expected []Amount
+	}{
+		{100, []int{1, 1}, []Amount{50, 50}},
+		{100, []int{70, 30}, []Amount{70, 30}},
+		{5, []int{3, 7}, []Amount{2, 3}},
+		{10, []int{1, 1, 1}, []Amount{4, 3, 3}},
+		{10, []int{1, 2, 1}, []Amount{3, 5, 2}},
+		{-10, []int{1, 2, 1}, []Amount{-3, -5, -2}},
+		{1, []int{1, 1, 1}, []Amount{1, 0, 0}},
+		{100, []int{0, 1}, []Amount{0, 100}},
+		{100, []int{0, 0}, nil},
+		{100, []int{1, -1}, nil},
+		{100, nil, nil},
+		{math.MaxInt64, []int{1, 1}, []Amount{math.MaxInt64/2 + 1, math.MaxInt64 / 2}},
+		{100, []int{maxRatio, 2}, []Amount{100, 0}},
+		{100, []int{maxRatio, maxRatio}, []Amount{50, 50}},
+	}
+
+	for _, c := range cases {
+		result := c.in.Allocate(c.ratios)
+
+		if !reflect.DeepEqual(result, c.expected) {
+			t.Errorf("%d.Allocate(%v) -> %v, %v expected", c.in, c.ratios, result, c.expected)
+		}
+	}
+}
+
+func TestAllocateSum(t *testing.T) {
+	amounts := []Amount{1, 999, -12345, BTC + 1, AllBTC, math.MaxInt64, math.MinInt64}
+	maxRatio := int(^uint(0) >> 1)
+	ratios := [][]int{{1, 2, 3}, {7, 0, 13, 1}, {math.MaxInt32, math.MaxInt32, 1}, {maxRatio, 2}, {maxRatio, maxRatio, maxRatio}}
+
+	for _, a := range amounts {
+		for _, r := range ratios {
+			sum, err := SumAmounts(a.Allocate(r))
+
+			if sum != a || err != nil {
+				t.Errorf("%d.Allocate(%v) sums to %d, %v", a, r, sum, err)
+			}
+		}
+	}
+}
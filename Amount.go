@@ -1,10 +1,15 @@
 package bitcoin
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 // Amount is an integer precision type representing an amount in Satoshis.
@@ -25,6 +30,15 @@ const (
 	AllBTC Amount = 20999999*BTC + 97690000*Satoshi
 )
 
+var (
+	// ErrOverflow is returned when a result does not fit in an Amount.
+	ErrOverflow = errors.New("amount overflows int64")
+
+	// ErrPrecision is returned by ParseStrict when the input is more
+	// precise than a satoshi, like "0.123456789".
+	ErrPrecision = errors.New("amount is more precise than a satoshi")
+)
+
 // Float64 returns the amount as floats of unit. For example
 // calling Float64(MilliBTC) on 1.5BTC will return 1500.0.
 // Note: This must not be used for calculations as precision
@@ -46,11 +60,124 @@ func (a Amount) Abs() Amount {
 	return a
 }
 
+// Cmp compares a and b and returns -1 if a < b, 0 if a == b and
+// +1 if a > b.
+func (a Amount) Cmp(b Amount) int {
+	switch {
+	case a < b:
+		return -1
+
+	case a > b:
+		return 1
+
+	default:
+		return 0
+	}
+}
+
+// Between reports whether lo <= a <= hi.
+func (a Amount) Between(lo, hi Amount) bool {
+	return lo <= a && a <= hi
+}
+
+// Clamp returns a limited to the range from min to max, inclusive.
+// min must not be greater than max.
+func (a Amount) Clamp(min, max Amount) Amount {
+	switch {
+	case a < min:
+		return min
+
+	case a > max:
+		return max
+
+	default:
+		return a
+	}
+}
+
+// AddChecked returns a+b, or ErrOverflow if the sum does not fit
+// in an Amount.
+func (a Amount) AddChecked(b Amount) (Amount, error) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, ErrOverflow
+	}
+
+	return a + b, nil
+}
+
+// SubChecked returns a-b, or ErrOverflow if the difference does not
+// fit in an Amount.
+func (a Amount) SubChecked(b Amount) (Amount, error) {
+	if (b < 0 && a > math.MaxInt64+b) || (b > 0 && a < math.MinInt64+b) {
+		return 0, ErrOverflow
+	}
+
+	return a - b, nil
+}
+
+// MulChecked returns a*n, or ErrOverflow if the product does not
+// fit in an Amount.
+func (a Amount) MulChecked(n int64) (Amount, error) {
+	if a == 0 || n == 0 {
+		return 0, nil
+	}
+
+	product := a * Amount(n)
+
+	if product/Amount(n) != a || (a == -1 && n == math.MinInt64) || (n == -1 && a == math.MinInt64) {
+		return 0, ErrOverflow
+	}
+
+	return product, nil
+}
+
+// MulBasisPoints returns a multiplied by bps/10000, rounded to a
+// satoshi using mode. A 0.25% taker fee rounded up is
+// a.MulBasisPoints(25, RoundCeil). The intermediate product cannot
+// overflow, but like ordinary multiplication the result wraps if it
+// does not fit in an Amount; use MulBasisPointsChecked to detect
+// that.
+func (a Amount) MulBasisPoints(bps int, mode RoundingMode) Amount {
+	result := a.mulBasisPoints(bps, mode)
+
+	// Keep the low 64 bits, like int64 multiplication does.
+	result.Mod(result, new(big.Int).Lsh(big.NewInt(1), 64))
+
+	return Amount(result.Uint64())
+}
+
+// MulBasisPointsChecked works like MulBasisPoints, but returns
+// ErrOverflow if the result does not fit in an Amount.
+func (a Amount) MulBasisPointsChecked(bps int, mode RoundingMode) (Amount, error) {
+	result := a.mulBasisPoints(bps, mode)
+	if !result.IsInt64() {
+		return 0, ErrOverflow
+	}
+
+	return Amount(result.Int64()), nil
+}
+
+func (a Amount) mulBasisPoints(bps int, mode RoundingMode) *big.Int {
+	num := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(int64(bps)))
+
+	return bigDivRound(num, big.NewInt(10000), mode)
+}
+
+// MulPercent returns a multiplied by percent/100, rounded to a
+// satoshi using mode. The result wraps like MulBasisPoints.
+func (a Amount) MulPercent(percent int, mode RoundingMode) Amount {
+	return a.MulBasisPoints(percent*100, mode)
+}
+
+// AppendText implements encoding.TextAppender, appending the
+// MarshalText form of a to b.
+func (a Amount) AppendText(b []byte) ([]byte, error) {
+	return a.appendSplit(b, BTC, false), nil
+}
+
 // MarshalText implements encoding.TextMarshaler.
 func (a Amount) MarshalText() (text []byte, err error) {
-	left, right := a.SplitString(BTC)
-
-	return []byte(left + "." + right), nil
+	return a.AppendText(make([]byte, 0, 24))
 }
 
 // UnmarshalText imeplemts encoding.TextUnmarshaler.
@@ -93,140 +220,289 @@ func (a *Amount) UnmarshalJSON(in []byte) error {
 	return nil
 }
 
-func (a Amount) split(unit Amount) (int, int) {
-	if a == 0 {
+// split returns the whole number of units in a, truncated towards
+// zero, and the magnitude of the remainder. A unit of 0 gives 0, 0.
+func (a Amount) split(unit Amount) (int64, uint64) {
+	if unit == 0 {
 		return 0, 0
 	}
 
-	if unit == 0 {
-		return 0, -1
-	}
+	left := a / unit
 
-	right := a % unit
-	left := (a - right) / unit
+	// The remainder is computed as uint64 so its magnitude fits for
+	// math.MinInt64.
+	right := uint64(a % unit)
+	if a < 0 {
+		right = -right
+	}
 
-	return int(left), int(right.Abs())
+	return int64(left), right
 }
 
 // SplitString splits the value as two strings at pos. Pos 0
 // is the decimal point in BTC. SplitString(0) for 1.055000 BTC
 // will result in the values "1" and "055".
 // If amount is not equal to 10^x for some integer value of x,
-// the result is undefined.
+// the result is undefined. A unit of 0 is invalid and gives "0" and
+// "0".
 func (a Amount) SplitString(unit Amount) (string, string) {
-	left, right := a.split(unit)
+	var buf [32]byte
 
-	log10 := int(math.Log10(float64(unit)))
-	rightFormat := fmt.Sprintf("%%0%dd", log10)
+	out := a.appendSplit(buf[:0], unit, false)
+	point := bytes.IndexByte(out, '.')
 
-	leftStr := fmt.Sprintf("%d", left)
-	rightStr := fmt.Sprintf(rightFormat, right)
+	return string(out[:point]), string(out[point+1:])
+}
 
-	for i := len(rightStr) - 1; i > 0; i-- {
-		if rightStr[i] != '0' {
-			break
+// appendSplit appends the whole part of a in unit, a decimal point
+// and the fraction with trailing zeros removed. A zero fraction is
+// left out with the decimal point if omitZero is set.
+func (a Amount) appendSplit(dst []byte, unit Amount, omitZero bool) []byte {
+	if unit == 0 {
+		dst = append(dst, '0')
+		if !omitZero {
+			dst = append(dst, ".0"...)
 		}
 
-		rightStr = rightStr[0:i]
+		return dst
+	}
+
+	left, right := a.split(unit)
+
+	if a < 0 && left == 0 {
+		// The sign is lost when the whole part is zero.
+		dst = append(dst, '-')
+	}
+
+	dst = strconv.AppendInt(dst, left, 10)
+
+	if right == 0 && omitZero {
+		return dst
 	}
 
-	return leftStr, rightStr
+	dst = append(dst, '.')
+
+	digits := 0
+	for u := unit; u >= 10; u /= 10 {
+		digits++
+	}
+
+	// Trailing zeros are dropped, keeping at least one digit.
+	for digits > 1 && right%10 == 0 {
+		right /= 10
+		digits--
+	}
+
+	n := 1
+	for r := right; r >= 10; r /= 10 {
+		n++
+	}
+
+	if n < digits {
+		dst = append(dst, zeros[:digits-n]...)
+	}
+
+	return strconv.AppendUint(dst, right, 10)
+}
+
+// zeros pads fractions, which have at most 18 digits.
+const zeros = "000000000000000000"
+
+// AppendFormat appends a formatted like Format to dst.
+func (a Amount) AppendFormat(dst []byte, unit Amount) []byte {
+	return a.appendSplit(dst, unit, true)
 }
 
 // Format will return a string representing the amount in units
 // of unit. For example Format(MilliBTC) returns "1500" for an
 // amount of 1.5 BTC.
 // If amount is not equal to 10^x for some integer value of x,
-// the result is undefined.
+// the result is undefined. A unit of 0 is invalid and gives "0".
 func (a Amount) Format(unit Amount) string {
-	left, right := a.SplitString(unit)
+	var buf [32]byte
 
-	if right == "0" {
-		return left
-	}
-
-	return left + "." + right
+	return string(a.AppendFormat(buf[:0], unit))
 }
 
-// String implements fmt.Stringer.
-func (a Amount) String() string {
+// Append appends a formatted like String to dst.
+func (a Amount) Append(dst []byte) []byte {
 	switch {
 	case a.Abs() > BTC, a == 0:
-		return a.Format(BTC) + " BTC"
+		return append(a.AppendFormat(dst, BTC), " BTC"...)
 
 	case a.Abs() > MilliBTC:
-		return a.Format(MilliBTC) + " mBTC"
+		return append(a.AppendFormat(dst, MilliBTC), " mBTC"...)
+
+	case a.Abs() > MicroBTC:
+		return append(a.AppendFormat(dst, MicroBTC), " bits"...)
 
 	default:
-		return a.Format(Satoshi) + " sats"
+		return append(a.AppendFormat(dst, Satoshi), " sats"...)
 	}
 }
 
+// String implements fmt.Stringer.
+func (a Amount) String() string {
+	var buf [32]byte
+
+	return string(a.Append(buf[:0]))
+}
+
+// Bits returns the amount formatted in bits, where one bit is a
+// µBTC or 100 satoshis, like "1.5 bits".
+func (a Amount) Bits() string {
+	return a.Format(MicroBTC) + " bits"
+}
+
 // Parse parses a numeric string representing a value in
 // bitcoin. Parse assumes the value is a decimal or
 // integer. "1.4" will be parsed as 1.4 BTC. "1" will
 // be parsed as 1.0 BTC.
+// ErrOverflow is returned if the value does not fit in an Amount.
+// Decimals beyond the 8th are ignored.
 func Parse(in string) (Amount, error) {
-	value := 0 * Satoshi
-	decimals := false
-	negative := false
+	return parse(in, BTC, false)
+}
+
+// MustParse is like Parse but panics if the input cannot be parsed.
+// It is meant for constants and test fixtures.
+func MustParse(in string) Amount {
+	a, err := Parse(in)
+	if err != nil {
+		panic("bitcoin: MustParse(" + strconv.Quote(in) + "): " + err.Error())
+	}
+
+	return a
+}
 
-	mul := 100 * MilliBTC
+// ParseAll parses every string in in using Parse. If any of them
+// fail, the error identifies the first failing index.
+func ParseAll(in []string) ([]Amount, error) {
+	amounts := make([]Amount, len(in))
 
-	add := func(add Amount) {
-		if !decimals {
-			add *= BTC
-			value *= 10
-		} else {
-			add *= mul
-			mul /= 10
+	for i, s := range in {
+		a, err := Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("amount %d: %w", i, err)
 		}
 
-		value += add
+		amounts[i] = a
 	}
 
-	runeVal := func(r rune) Amount {
-		v := Amount(r) - '0'
+	return amounts, nil
+}
+
+// ParseStrict works like Parse, but returns ErrPrecision instead of
+// ignoring decimals beyond the 8th.
+func ParseStrict(in string) (Amount, error) {
+	return parse(in, BTC, true)
+}
+
+// units maps the suffixes understood by ParseWithUnit to their
+// unit. Longer suffixes come first so "mBTC" is not read as "BTC".
+var units = []struct {
+	suffix string
+	unit   Amount
+}{
+	{"mBTC", MilliBTC},
+	{"µBTC", MicroBTC},
+	{"μBTC", MicroBTC},
+	{"uBTC", MicroBTC},
+	{"BTC", BTC},
+	{"btc", BTC},
+	{"bits", MicroBTC},
+	{"bit", MicroBTC},
+	{"sats", Satoshi},
+	{"sat", Satoshi},
+}
+
+// ParseWithUnit works like Parse, but accepts an optional unit
+// suffix: "BTC", "mBTC", "µBTC" or "uBTC", "bits" and "sats" or
+// "sat". "1.5 mBTC" will be parsed as 150000 satoshis. Without a
+// suffix the value is read as BTC. ParseWithUnit accepts the
+// output of String.
+func ParseWithUnit(in string) (Amount, error) {
+	in = strings.TrimSpace(in)
 
-		return v
+	for _, u := range units {
+		if strings.HasSuffix(in, u.suffix) {
+			number := strings.TrimSpace(strings.TrimSuffix(in, u.suffix))
+
+			return parse(number, u.unit, false)
+		}
 	}
 
-	// Collect whole BTC before the comma.
-	for pos, r := range in {
+	return parse(in, BTC, false)
+}
 
-		switch r {
-		case '+':
-			if pos == 0 {
-				break
-			} else {
-				return 0, errors.New("parse error, stray +")
-			}
+// parse parses in as a number of unit, which must be a power of ten
+// satoshis.
+func parse(in string, unit Amount, strict bool) (Amount, error) {
+	var value Amount
+	var err error
+
+	decimals := false
+
+	// The sign is applied to every digit as it is added, so that
+	// the full range down to math.MinInt64 can be parsed.
+	sign := Amount(1)
+
+	mul := unit / 10
 
-		case '-':
-			if pos == 0 {
-				negative = true
+	// Work on bytes; only the error path needs to decode runes.
+	for i := 0; i < len(in); i++ {
+		c := in[i]
+
+		switch {
+		case c >= '0' && c <= '9':
+			digit := Amount(c - '0')
+
+			if !decimals {
+				value, err = value.MulChecked(10)
+				if err != nil {
+					return 0, err
+				}
+
+				digit *= unit
 			} else {
-				return 0, errors.New("parse error, stray -")
+				if mul == 0 && strict {
+					return 0, ErrPrecision
+				}
+
+				digit *= mul
+				mul /= 10
 			}
 
-		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-			add(runeVal(r))
+			value, err = value.AddChecked(sign * digit)
+			if err != nil {
+				return 0, err
+			}
 
-		case '.', ',':
+		case c == '.' || c == ',':
 			if decimals {
 				return 0, errors.New("parse error, too many decimal points")
 			}
 
 			decimals = true
 
+		case c == '+':
+			if i != 0 {
+				return 0, errors.New("parse error, stray +")
+			}
+
+		case c == '-':
+			if i != 0 {
+				return 0, errors.New("parse error, stray -")
+			}
+
+			sign = -1
+
 		default:
+			r, _ := utf8.DecodeRuneInString(in[i:])
+
 			return 0, errors.New("parse error, unknown character: " + string(r) + " of '" + in + "'")
 		}
 	}
 
-	if negative {
-		return -value, nil
-	}
-
 	return value, nil
 }
@@ -1,8 +1,10 @@
 package bitcoin
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"reflect"
 	"testing"
 )
 
@@ -65,11 +67,14 @@ func TestString(t *testing.T) {
 		{"2", "2 BTC"},
 		{"2.01", "2.01 BTC"},
 		{"0.002", "2 mBTC"},
-		{"0.00023", "23000 sats"},
+		{"0.00023", "230 bits"},
+		{"0.00000123", "1.23 bits"},
+		{"0.000001", "100 sats"},
 		{"-2", "-2 BTC"},
 		{"-2.01", "-2.01 BTC"},
 		{"-0.002", "-2 mBTC"},
-		{"-0.00023", "-23000 sats"},
+		{"-0.00023", "-230 bits"},
+		{"-0.00000001", "-1 sats"},
 		{max, "92233720368 BTC"},
 		{min, "-92233720368 BTC"},
 	}
@@ -84,14 +89,43 @@ func TestString(t *testing.T) {
 	}
 }
 
+func TestBits(t *testing.T) {
+	cases := []struct {
+		in       Amount
+		expected string
+	}{
+		{0, "0 bits"},
+		{MicroBTC, "1 bits"},
+		{150 * Satoshi, "1.5 bits"},
+		{BTC, "1000000 bits"},
+		{-Satoshi, "-0.01 bits"},
+	}
+
+	for _, c := range cases {
+		result := c.in.Bits()
+
+		if result != c.expected {
+			t.Errorf("%d.Bits() -> '%s', '%s' expected", c.in, result, c.expected)
+		}
+
+		back, err := ParseWithUnit(result)
+		if back != c.in || err != nil {
+			t.Errorf("ParseWithUnit('%s') -> %d, %v, %d expected", result, back, err, c.in)
+		}
+	}
+}
+
 func TestSplit(t *testing.T) {
 	cases := []struct {
 		in    string
 		pos   Amount
-		part1 int
-		part2 int
+		part1 int64
+		part2 uint64
 	}{
 		{"0", 0, 0, 0},
+		{"1", 0, 0, 0},
+		{"-1", 0, 0, 0},
+		{"0", Satoshi, 0, 0},
 		{"0.12345678", BTC, 0, 12345678},
 		{"0.12345678", 100 * MilliBTC, 1, 2345678},
 		{"0.12345678", 10 * MilliBTC, 12, 345678},
@@ -125,6 +159,9 @@ func TestSplit(t *testing.T) {
 		{"10.12345678", 10000 * BTC, 0, 1012345678},
 		{max, BTC, 92233720368, 0},
 		{min, BTC, -92233720368, 0},
+		{"-0.00000001", BTC, 0, 1},
+		{"-92233720368.54775808", Satoshi, math.MinInt64, 0},
+		{"-92233720368.54775808", BTC, -92233720368, 54775808},
 	}
 
 	for _, c := range cases {
@@ -164,6 +201,11 @@ func TestSplitString(t *testing.T) {
 		{"1.12345678", 10 * Satoshi, "11234567", "8"},
 		{"1.12345678", Satoshi, "112345678", "0"},
 		{"10.0012", BTC, "10", "0012"},
+		{"-0.5", BTC, "-0", "5"},
+		{"-0.00000001", BTC, "-0", "00000001"},
+		{"-0.00023", MilliBTC, "-0", "23"},
+		{"1.5", 0, "0", "0"},
+		{"-1.5", 0, "0", "0"},
 		{"10.12345678", BTC, "10", "12345678"},
 		{"10.12345678", 100 * MilliBTC, "101", "2345678"},
 		{"10.12345678", 10 * MilliBTC, "1012", "345678"},
@@ -190,3 +232,457 @@ func TestSplitString(t *testing.T) {
 		}
 	}
 }
+
+func TestAddChecked(t *testing.T) {
+	cases := []struct {
+		a        Amount
+		b        Amount
+		expected Amount
+		err      error
+	}{
+		{0, 0, 0, nil},
+		{BTC, Satoshi, BTC + Satoshi, nil},
+		{BTC, -2 * BTC, -BTC, nil},
+		{math.MaxInt64 - 1, 1, math.MaxInt64, nil},
+		{math.MaxInt64, 1, 0, ErrOverflow},
+		{math.MinInt64 + 1, -1, math.MinInt64, nil},
+		{math.MinInt64, -1, 0, ErrOverflow},
+		{math.MaxInt64, math.MinInt64, -1, nil},
+	}
+
+	for _, c := range cases {
+		result, err := c.a.AddChecked(c.b)
+
+		if result != c.expected || err != c.err {
+			t.Errorf("%d.AddChecked(%d) -> %d, %v, %d, %v expected", c.a, c.b, result, err, c.expected, c.err)
+		}
+	}
+}
+
+func TestSubChecked(t *testing.T) {
+	cases := []struct {
+		a        Amount
+		b        Amount
+		expected Amount
+		err      error
+	}{
+		{0, 0, 0, nil},
+		{BTC, Satoshi, BTC - Satoshi, nil},
+		{BTC, -2 * BTC, 3 * BTC, nil},
+		{math.MaxInt64 - 1, -1, math.MaxInt64, nil},
+		{math.MaxInt64, -1, 0, ErrOverflow},
+		{math.MinInt64 + 1, 1, math.MinInt64, nil},
+		{math.MinInt64, 1, 0, ErrOverflow},
+		{0, math.MinInt64, 0, ErrOverflow},
+		{-1, math.MinInt64, math.MaxInt64, nil},
+	}
+
+	for _, c := range cases {
+		result, err := c.a.SubChecked(c.b)
+
+		if result != c.expected || err != c.err {
+			t.Errorf("%d.SubChecked(%d) -> %d, %v, %d, %v expected", c.a, c.b, result, err, c.expected, c.err)
+		}
+	}
+}
+
+func TestMulChecked(t *testing.T) {
+	cases := []struct {
+		a        Amount
+		n        int64
+		expected Amount
+		err      error
+	}{
+		{0, 0, 0, nil},
+		{BTC, 0, 0, nil},
+		{0, math.MaxInt64, 0, nil},
+		{BTC, 3, 3 * BTC, nil},
+		{BTC, -3, -3 * BTC, nil},
+		{AllBTC, 4392, 4392 * AllBTC, nil},
+		{AllBTC, 4393, 0, ErrOverflow},
+		{math.MaxInt64, -1, -math.MaxInt64, nil},
+		{math.MinInt64, -1, 0, ErrOverflow},
+		{-1, math.MinInt64, 0, ErrOverflow},
+		{math.MinInt64, 1, math.MinInt64, nil},
+	}
+
+	for _, c := range cases {
+		result, err := c.a.MulChecked(c.n)
+
+		if result != c.expected || err != c.err {
+			t.Errorf("%d.MulChecked(%d) -> %d, %v, %d, %v expected", c.a, c.n, result, err, c.expected, c.err)
+		}
+	}
+}
+
+func TestParseOverflow(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected Amount
+		err      error
+	}{
+		{"92233720368.54775807", math.MaxInt64, nil},
+		{"92233720368.54775808", 0, ErrOverflow},
+		{"-92233720368.54775808", math.MinInt64, nil},
+		{"-92233720368.54775809", 0, ErrOverflow},
+		{"92233720369", 0, ErrOverflow},
+		{"-92233720369", 0, ErrOverflow},
+		{"100000000000", 0, ErrOverflow},
+		{"99999999999999999999.0", 0, ErrOverflow},
+		{"00000000000000000000092233720368", 92233720368 * BTC, nil},
+	}
+
+	for _, c := range cases {
+		result, err := Parse(c.in)
+
+		if result != c.expected || err != c.err {
+			t.Errorf("Parse('%s') -> %d, %v, %d, %v expected", c.in, result, err, c.expected, c.err)
+		}
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected Amount
+		err      error
+	}{
+		{"0", 0, nil},
+		{"1.5", BTC + 500*MilliBTC, nil},
+		{"0.12345678", 12345678 * Satoshi, nil},
+		{"-0.12345678", -12345678 * Satoshi, nil},
+		{"0.123456789", 0, ErrPrecision},
+		{"0.123456780", 0, ErrPrecision},
+		{"0.000000001", 0, ErrPrecision},
+		{"92233720369", 0, ErrOverflow},
+	}
+
+	for _, c := range cases {
+		result, err := ParseStrict(c.in)
+
+		if result != c.expected || err != c.err {
+			t.Errorf("ParseStrict('%s') -> %d, %v, %d, %v expected", c.in, result, err, c.expected, c.err)
+		}
+	}
+
+	// Parse keeps ignoring the extra decimals.
+	result, err := Parse("0.123456789")
+	if result != 12345678*Satoshi || err != nil {
+		t.Errorf("Parse('0.123456789') -> %d, %v, %d expected", result, err, 12345678*Satoshi)
+	}
+}
+
+func TestParseWithUnit(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected Amount
+		err      bool
+	}{
+		{"", 0, false},
+		{"1.5", BTC + 500*MilliBTC, false},
+		{"1.5 BTC", BTC + 500*MilliBTC, false},
+		{"1.5BTC", BTC + 500*MilliBTC, false},
+		{"1.5 btc", BTC + 500*MilliBTC, false},
+		{"1.5 mBTC", 1500 * MicroBTC, false},
+		{"-1.5 mBTC", -1500 * MicroBTC, false},
+		{"2 µBTC", 2 * MicroBTC, false},
+		{"2 μBTC", 2 * MicroBTC, false},
+		{"2 uBTC", 2 * MicroBTC, false},
+		{"2.5 bits", 250 * Satoshi, false},
+		{"1 bit", MicroBTC, false},
+		{"2100 sats", 2100 * Satoshi, false},
+		{"1 sat", Satoshi, false},
+		{" 21 sats ", 21 * Satoshi, false},
+		{"9223372036854775807 sats", math.MaxInt64, false},
+		{"9223372036854775808 sats", 0, true},
+		{"1.5 MBTC", 0, true},
+		{"1.5 sats BTC", 0, true},
+		{"1.5 ETH", 0, true},
+	}
+
+	for _, c := range cases {
+		result, err := ParseWithUnit(c.in)
+
+		if result != c.expected || (err != nil) != c.err {
+			t.Errorf("ParseWithUnit('%s') -> %d, %v, %d expected", c.in, result, err, c.expected)
+		}
+	}
+
+	// String output must round-trip.
+	for _, a := range []Amount{0, 2 * BTC, -2 * MilliBTC, 23000 * Satoshi, BTC + 10*MilliBTC, AllBTC} {
+		result, err := ParseWithUnit(a.String())
+
+		if result != a || err != nil {
+			t.Errorf("ParseWithUnit('%s') -> %d, %v, %d expected", a.String(), result, err, a)
+		}
+	}
+}
+
+func TestMulBasisPoints(t *testing.T) {
+	cases := []struct {
+		in       Amount
+		bps      int
+		mode     RoundingMode
+		expected Amount
+	}{
+		{0, 25, RoundCeil, 0},
+		{BTC, 25, RoundCeil, 250000},
+		{BTC, 10000, RoundCeil, BTC},
+		{BTC, 20000, RoundCeil, 2 * BTC},
+		{BTC, -25, RoundCeil, -250000},
+		{1001, 25, RoundCeil, 3},
+		{1001, 25, RoundFloor, 2},
+		{1001, 25, RoundHalfUp, 3},
+		{1000, 25, RoundHalfUp, 3},
+		{1000, 25, RoundHalfEven, 2},
+		{1000, 25, RoundTowardZero, 2},
+		{-1001, 25, RoundCeil, -2},
+		{-1001, 25, RoundFloor, -3},
+		{math.MaxInt64, 10000, RoundHalfUp, math.MaxInt64},
+		{math.MaxInt64, 5000, RoundFloor, math.MaxInt64 / 2},
+
+		// The result wraps like int64 multiplication.
+		{math.MaxInt64, 20000, RoundHalfUp, -2},
+		{math.MinInt64, 20000, RoundHalfUp, 0},
+		{math.MinInt64, -10000, RoundHalfUp, math.MinInt64},
+	}
+
+	for _, c := range cases {
+		result := c.in.MulBasisPoints(c.bps, c.mode)
+
+		if result != c.expected {
+			t.Errorf("%d.MulBasisPoints(%d, %d) -> %d, %d expected", c.in, c.bps, c.mode, result, c.expected)
+		}
+	}
+}
+
+func TestMulBasisPointsChecked(t *testing.T) {
+	cases := []struct {
+		in       Amount
+		bps      int
+		mode     RoundingMode
+		expected Amount
+		err      error
+	}{
+		{BTC, 25, RoundCeil, 250000, nil},
+		{-1001, 25, RoundFloor, -3, nil},
+		{math.MaxInt64, 10000, RoundHalfUp, math.MaxInt64, nil},
+		{math.MinInt64, 10000, RoundHalfUp, math.MinInt64, nil},
+		{math.MaxInt64, 10001, RoundFloor, 0, ErrOverflow},
+		{math.MaxInt64, 20000, RoundHalfUp, 0, ErrOverflow},
+		{math.MinInt64, -10000, RoundHalfUp, 0, ErrOverflow},
+	}
+
+	for _, c := range cases {
+		result, err := c.in.MulBasisPointsChecked(c.bps, c.mode)
+
+		if result != c.expected || err != c.err {
+			t.Errorf("%d.MulBasisPointsChecked(%d, %d) -> %d, %v, %d, %v expected", c.in, c.bps, c.mode, result, err, c.expected, c.err)
+		}
+	}
+}
+
+func TestMulPercent(t *testing.T) {
+	cases := []struct {
+		in       Amount
+		percent  int
+		mode     RoundingMode
+		expected Amount
+	}{
+		{BTC, 1, RoundHalfUp, 10 * MilliBTC},
+		{BTC, 100, RoundHalfUp, BTC},
+		{3, 50, RoundHalfUp, 2},
+		{3, 50, RoundHalfEven, 2},
+		{5, 50, RoundHalfEven, 2},
+		{5, 50, RoundCeil, 3},
+	}
+
+	for _, c := range cases {
+		result := c.in.MulPercent(c.percent, c.mode)
+
+		if result != c.expected {
+			t.Errorf("%d.MulPercent(%d, %d) -> %d, %d expected", c.in, c.percent, c.mode, result, c.expected)
+		}
+	}
+}
+
+func TestCmp(t *testing.T) {
+	cases := []struct {
+		a        Amount
+		b        Amount
+		expected int
+	}{
+		{0, 0, 0},
+		{BTC, BTC, 0},
+		{Satoshi, BTC, -1},
+		{BTC, Satoshi, 1},
+		{-BTC, Satoshi, -1},
+		{math.MinInt64, math.MaxInt64, -1},
+	}
+
+	for _, c := range cases {
+		result := c.a.Cmp(c.b)
+
+		if result != c.expected {
+			t.Errorf("%d.Cmp(%d) -> %d, %d expected", c.a, c.b, result, c.expected)
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	cases := []struct {
+		in       Amount
+		lo       Amount
+		hi       Amount
+		expected bool
+	}{
+		{MilliBTC, Satoshi, BTC, true},
+		{Satoshi, Satoshi, BTC, true},
+		{BTC, Satoshi, BTC, true},
+		{0, Satoshi, BTC, false},
+		{BTC + 1, Satoshi, BTC, false},
+		{0, BTC, Satoshi, false},
+	}
+
+	for _, c := range cases {
+		result := c.in.Between(c.lo, c.hi)
+
+		if result != c.expected {
+			t.Errorf("%d.Between(%d, %d) -> %v, %v expected", c.in, c.lo, c.hi, result, c.expected)
+		}
+	}
+}
+
+func TestClamp(t *testing.T) {
+	cases := []struct {
+		in       Amount
+		min      Amount
+		max      Amount
+		expected Amount
+	}{
+		{MilliBTC, Satoshi, BTC, MilliBTC},
+		{0, Satoshi, BTC, Satoshi},
+		{2 * BTC, Satoshi, BTC, BTC},
+		{-BTC, -MilliBTC, MilliBTC, -MilliBTC},
+		{BTC, BTC, BTC, BTC},
+	}
+
+	for _, c := range cases {
+		result := c.in.Clamp(c.min, c.max)
+
+		if result != c.expected {
+			t.Errorf("%d.Clamp(%d, %d) -> %d, %d expected", c.in, c.min, c.max, result, c.expected)
+		}
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	if MustParse("1.5") != BTC+500*MilliBTC {
+		t.Errorf("MustParse('1.5') -> %d, %d expected", MustParse("1.5"), BTC+500*MilliBTC)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustParse('x') did not panic")
+		}
+	}()
+
+	MustParse("x")
+}
+
+func TestParseAll(t *testing.T) {
+	result, err := ParseAll([]string{"1", "0.5", "-0.00000001"})
+	expected := []Amount{BTC, 500 * MilliBTC, -Satoshi}
+
+	if !reflect.DeepEqual(result, expected) || err != nil {
+		t.Errorf("ParseAll() -> %v, %v, %v expected", result, err, expected)
+	}
+
+	result, err = ParseAll(nil)
+	if len(result) != 0 || err != nil {
+		t.Errorf("ParseAll(nil) -> %v, %v", result, err)
+	}
+
+	result, err = ParseAll([]string{"1", "92233720369", "x"})
+	if result != nil || err == nil || !errors.Is(err, ErrOverflow) || err.Error() != "amount 1: amount overflows int64" {
+		t.Errorf("ParseAll() -> %v, %v", result, err)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = Parse("20999999.9769")
+	}
+}
+
+func BenchmarkParseStrict(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseStrict("-0.00012345")
+	}
+}
+
+func BenchmarkParseWithUnit(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseWithUnit("1500.5 mBTC")
+	}
+}
+
+func TestAppend(t *testing.T) {
+	amounts := []Amount{0, Satoshi, -Satoshi, 23000, -2 * MilliBTC, BTC, -500 * MilliBTC, math.MaxInt64, math.MinInt64}
+
+	for _, a := range amounts {
+		prefix := []byte("x=")
+
+		if result := string(a.Append(prefix)); result != "x="+a.String() {
+			t.Errorf("%d.Append() -> '%s', 'x=%s' expected", a, result, a.String())
+		}
+
+		for _, unit := range []Amount{0, Satoshi, MicroBTC, MilliBTC, BTC, 10 * BTC} {
+			if result := string(a.AppendFormat(prefix, unit)); result != "x="+a.Format(unit) {
+				t.Errorf("%d.AppendFormat(%d) -> '%s', 'x=%s' expected", a, unit, result, a.Format(unit))
+			}
+		}
+
+		text, _ := a.MarshalText()
+
+		result, err := a.AppendText(prefix)
+		if string(result) != "x="+string(text) || err != nil {
+			t.Errorf("%d.AppendText() -> '%s', %v, 'x=%s' expected", a, result, err, text)
+		}
+	}
+}
+
+func TestFormatZeroUnit(t *testing.T) {
+	for _, a := range []Amount{0, BTC, -BTC} {
+		if result := a.Format(0); result != "0" {
+			t.Errorf("%d.Format(0) -> '%s', '0' expected", a, result)
+		}
+	}
+}
+
+func BenchmarkAppend(b *testing.B) {
+	b.ReportAllocs()
+
+	buf := make([]byte, 0, 64)
+	a := 2*BTC + 10*MilliBTC
+
+	for i := 0; i < b.N; i++ {
+		buf = a.Append(buf[:0])
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	b.ReportAllocs()
+
+	a := 2*BTC + 10*MilliBTC
+
+	for i := 0; i < b.N; i++ {
+		_ = a.String()
+	}
+}
@@ -0,0 +1,224 @@
+package bitcoin
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// BigAmount is an arbitrary precision amount in satoshis, for totals
+// that can exceed the range of Amount, like cumulative volumes.
+// BigAmount values are immutable; arithmetic returns new values. The
+// zero value is 0.
+type BigAmount struct {
+	sats *big.Int
+}
+
+// NewBigAmount returns a as a BigAmount.
+func NewBigAmount(a Amount) BigAmount {
+	return BigAmount{big.NewInt(int64(a))}
+}
+
+// NewBigAmountFromInt returns a BigAmount of sats satoshis.
+func NewBigAmountFromInt(sats *big.Int) BigAmount {
+	return BigAmount{new(big.Int).Set(sats)}
+}
+
+func (b BigAmount) int() *big.Int {
+	if b.sats == nil {
+		return new(big.Int)
+	}
+
+	return b.sats
+}
+
+// Int returns the amount in satoshis.
+func (b BigAmount) Int() *big.Int {
+	return new(big.Int).Set(b.int())
+}
+
+// Amount returns b as an Amount, or ErrOverflow if it does not fit.
+func (b BigAmount) Amount() (Amount, error) {
+	if !b.int().IsInt64() {
+		return 0, ErrOverflow
+	}
+
+	return Amount(b.int().Int64()), nil
+}
+
+// Add returns b+c.
+func (b BigAmount) Add(c BigAmount) BigAmount {
+	return BigAmount{new(big.Int).Add(b.int(), c.int())}
+}
+
+// Sub returns b-c.
+func (b BigAmount) Sub(c BigAmount) BigAmount {
+	return BigAmount{new(big.Int).Sub(b.int(), c.int())}
+}
+
+// Mul returns b*n.
+func (b BigAmount) Mul(n int64) BigAmount {
+	return BigAmount{new(big.Int).Mul(b.int(), big.NewInt(n))}
+}
+
+// Neg returns -b.
+func (b BigAmount) Neg() BigAmount {
+	return BigAmount{new(big.Int).Neg(b.int())}
+}
+
+// Abs returns the absolute value.
+func (b BigAmount) Abs() BigAmount {
+	return BigAmount{new(big.Int).Abs(b.int())}
+}
+
+// Cmp compares b and c and returns -1 if b < c, 0 if b == c and
+// +1 if b > c.
+func (b BigAmount) Cmp(c BigAmount) int {
+	return b.int().Cmp(c.int())
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of b.
+func (b BigAmount) Sign() int {
+	return b.int().Sign()
+}
+
+// SplitString works like Amount.SplitString.
+func (b BigAmount) SplitString(unit Amount) (string, string) {
+	if unit == 0 {
+		return "0", "0"
+	}
+
+	left, right := new(big.Int).QuoRem(b.int(), big.NewInt(int64(unit)), new(big.Int))
+	right.Abs(right)
+
+	digits := len(big.NewInt(int64(unit)).String()) - 1
+
+	leftStr := left.String()
+	if b.Sign() < 0 && left.Sign() == 0 {
+		leftStr = "-0"
+	}
+
+	rightStr := right.String()
+	if len(rightStr) < digits {
+		rightStr = strings.Repeat("0", digits-len(rightStr)) + rightStr
+	}
+
+	for i := len(rightStr) - 1; i > 0; i-- {
+		if rightStr[i] != '0' {
+			break
+		}
+
+		rightStr = rightStr[0:i]
+	}
+
+	return leftStr, rightStr
+}
+
+// Format works like Amount.Format.
+func (b BigAmount) Format(unit Amount) string {
+	left, right := b.SplitString(unit)
+
+	if right == "0" {
+		return left
+	}
+
+	return left + "." + right
+}
+
+// String implements fmt.Stringer, choosing a unit like
+// Amount.String.
+func (b BigAmount) String() string {
+	abs := b.Abs()
+
+	switch {
+	case abs.Cmp(NewBigAmount(BTC)) > 0, b.Sign() == 0:
+		return b.Format(BTC) + " BTC"
+
+	case abs.Cmp(NewBigAmount(MilliBTC)) > 0:
+		return b.Format(MilliBTC) + " mBTC"
+
+	case abs.Cmp(NewBigAmount(MicroBTC)) > 0:
+		return b.Format(MicroBTC) + " bits"
+
+	default:
+		return b.Format(Satoshi) + " sats"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (b BigAmount) MarshalText() ([]byte, error) {
+	left, right := b.SplitString(BTC)
+
+	return []byte(left + "." + right), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *BigAmount) UnmarshalText(text []byte) error {
+	decoded, err := ParseBig(string(text))
+	if err != nil {
+		return err
+	}
+
+	*b = decoded
+
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Both strings and
+// numbers are accepted.
+func (b *BigAmount) UnmarshalJSON(in []byte) error {
+	if len(in) > 2 && in[len(in)-1] == '"' && in[0] == '"' {
+		in = in[1 : len(in)-1]
+	}
+
+	return b.UnmarshalText(in)
+}
+
+// ParseBig parses a decimal number of bitcoin like Parse, but
+// without limits on the size of the value.
+func ParseBig(in string) (BigAmount, error) {
+	s := in
+	negative := false
+
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	whole := s
+	frac := ""
+
+	if i := strings.IndexAny(s, ".,"); i >= 0 {
+		whole = s[:i]
+		frac = s[i+1:]
+	}
+
+	for _, part := range []string{whole, frac} {
+		for _, r := range part {
+			switch {
+			case r >= '0' && r <= '9':
+
+			case r == '.' || r == ',':
+				return BigAmount{}, errors.New("parse error, too many decimal points")
+
+			default:
+				return BigAmount{}, errors.New("parse error, unknown character: " + string(r) + " of '" + in + "'")
+			}
+		}
+	}
+
+	// Like Parse, decimals beyond the 8th are ignored.
+	if len(frac) > 8 {
+		frac = frac[:8]
+	}
+
+	frac += strings.Repeat("0", 8-len(frac))
+
+	sats, _ := new(big.Int).SetString("0"+whole+frac, 10)
+
+	if negative {
+		sats.Neg(sats)
+	}
+
+	return BigAmount{sats}, nil
+}
@@ -0,0 +1,142 @@
+package bitcoin
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestBigAmountMatchesAmount(t *testing.T) {
+	amounts := []Amount{
+		0, Satoshi, -Satoshi, 23000, -23000, 2 * MilliBTC, -2 * MilliBTC,
+		BTC, 2*BTC + 10*MilliBTC, -500 * MilliBTC, AllBTC, math.MaxInt64, math.MinInt64,
+	}
+	units := []Amount{0, Satoshi, MicroBTC, MilliBTC, BTC, 10 * BTC}
+
+	for _, a := range amounts {
+		b := NewBigAmount(a)
+
+		// Amount.Abs overflows for math.MinInt64, which makes
+		// Amount.String fall back to satoshis.
+		if b.String() != a.String() && a != math.MinInt64 {
+			t.Errorf("String() -> '%s', '%s' expected", b.String(), a.String())
+		}
+
+		for _, unit := range units {
+			if b.Format(unit) != a.Format(unit) {
+				t.Errorf("%d.Format(%d) -> '%s', '%s' expected", a, unit, b.Format(unit), a.Format(unit))
+			}
+		}
+
+		text, _ := b.MarshalText()
+		expected, _ := a.MarshalText()
+
+		if string(text) != string(expected) {
+			t.Errorf("MarshalText() -> '%s', '%s' expected", text, expected)
+		}
+
+		back, err := b.Amount()
+		if back != a || err != nil {
+			t.Errorf("Amount() -> %d, %v, %d expected", back, err, a)
+		}
+	}
+}
+
+func TestBigAmountArithmetic(t *testing.T) {
+	max := NewBigAmount(math.MaxInt64)
+
+	sum := max.Add(max).Add(NewBigAmount(2))
+	expected, _ := new(big.Int).SetString("18446744073709551616", 10)
+
+	if sum.Int().Cmp(expected) != 0 {
+		t.Errorf("Add() -> %s, %s expected", sum.Int(), expected)
+	}
+
+	if _, err := sum.Amount(); err != ErrOverflow {
+		t.Errorf("Amount() -> %v, %v expected", err, ErrOverflow)
+	}
+
+	if sum.Sub(max).Sub(max).Cmp(NewBigAmount(2)) != 0 {
+		t.Errorf("Sub() did not reverse Add()")
+	}
+
+	if max.Mul(2).Cmp(max.Add(max)) != 0 {
+		t.Errorf("Mul(2) differs from adding twice")
+	}
+
+	if max.Neg().Abs().Cmp(max) != 0 || max.Neg().Sign() != -1 {
+		t.Errorf("Neg() or Abs() failed")
+	}
+
+	var zero BigAmount
+	if zero.Sign() != 0 || zero.String() != "0 BTC" || zero.Add(NewBigAmount(BTC)).Cmp(NewBigAmount(BTC)) != 0 {
+		t.Errorf("zero value is not usable as 0")
+	}
+
+	// Operations must not modify their operands.
+	a := NewBigAmount(BTC)
+	a.Add(a)
+	a.Neg()
+
+	if a.Cmp(NewBigAmount(BTC)) != 0 {
+		t.Errorf("operand was modified: %s", a)
+	}
+}
+
+func TestParseBig(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected string
+		err      bool
+	}{
+		{"", "0", false},
+		{"0", "0", false},
+		{"1.5", "150000000", false},
+		{"1,5", "150000000", false},
+		{"-1.5", "-150000000", false},
+		{"+1.5", "150000000", false},
+		{".5", "50000000", false},
+		{"0.123456789", "12345678", false},
+		{"184467440737.09551616", "18446744073709551616", false},
+		{"1.2.3", "0", true},
+		{"1.5 BTC", "0", true},
+		{"--1", "0", true},
+		{"1-", "0", true},
+	}
+
+	for _, c := range cases {
+		result, err := ParseBig(c.in)
+
+		if result.Int().String() != c.expected || (err != nil) != c.err {
+			t.Errorf("ParseBig('%s') -> %s, %v, %s expected", c.in, result.Int(), err, c.expected)
+		}
+	}
+
+	for _, in := range []string{"0020.00000004", "-92233720368.54775808", "20999999.9769"} {
+		a, _ := Parse(in)
+		b, _ := ParseBig(in)
+
+		if b.Cmp(NewBigAmount(a)) != 0 {
+			t.Errorf("ParseBig('%s') -> %s, %s expected", in, b, a)
+		}
+	}
+}
+
+func TestBigAmountJSON(t *testing.T) {
+	huge, _ := ParseBig("184467440737.09551616")
+
+	out, err := json.Marshal(huge)
+	if string(out) != `"184467440737.09551616"` || err != nil {
+		t.Errorf("json.Marshal() -> %s, %v", out, err)
+	}
+
+	for _, in := range []string{`"184467440737.09551616"`, `184467440737.09551616`} {
+		var b BigAmount
+
+		err = json.Unmarshal([]byte(in), &b)
+		if b.Cmp(huge) != 0 || err != nil {
+			t.Errorf("json.Unmarshal(%s) -> %s, %v", in, b, err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package bitcoin
+
+import (
+	"sort"
+)
+
+// StandardDenominations returns the standard coinjoin output values
+// between min and max, inclusive, in ascending order. Like WabiSabi
+// coordinators it uses powers of two and three, twice the powers of
+// three, and the 1-2-5 series of powers of ten, all in satoshis.
+func StandardDenominations(min, max Amount) []Amount {
+	seen := make(map[Amount]bool)
+	var denominations []Amount
+
+	add := func(a Amount) {
+		if a >= min && a <= max && !seen[a] {
+			seen[a] = true
+			denominations = append(denominations, a)
+		}
+	}
+
+	for a := Satoshi; a > 0 && a <= max; a *= 2 {
+		add(a)
+	}
+
+	for a := Satoshi; a > 0 && a <= max; a *= 3 {
+		add(a)
+		add(2 * a)
+	}
+
+	for a := Satoshi; a > 0 && a <= max; a *= 10 {
+		add(a)
+		add(2 * a)
+		add(5 * a)
+	}
+
+	sort.Slice(denominations, func(i, j int) bool {
+		return denominations[i] < denominations[j]
+	})
+
+	return denominations
+}
+
+// Decompose splits a into outputs taken from denominations, largest
+// first. Whatever cannot be covered by a denomination is returned as
+// the remainder, which typically pays mining fees or becomes change.
+func Decompose(a Amount, denominations []Amount) ([]Amount, Amount) {
+	sorted := append([]Amount(nil), denominations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] > sorted[j]
+	})
+
+	var outputs []Amount
+
+	for _, d := range sorted {
+		if d <= 0 {
+			continue
+		}
+
+		for a >= d {
+			outputs = append(outputs, d)
+			a -= d
+		}
+	}
+
+	return outputs, a
+}
+
+// AnonymitySet estimates the anonymity set of an output of value a
+// in a round with the given outputs, as the number of outputs of
+// equal value. An output with a unique value has an anonymity set
+// of 1.
+func AnonymitySet(a Amount, outputs []Amount) int {
+	count := 0
+
+	for _, o := range outputs {
+		if o == a {
+			count++
+		}
+	}
+
+	if count == 0 {
+		return 1
+	}
+
+	return count
+}
+
+// CoordinatorFee returns the fee a coordinator charges for
+// registering an input of value a at a rate of bps basis points.
+// Inputs below freeBelow are not charged. The fee is rounded up to
+// the nearest satoshi.
+func CoordinatorFee(a Amount, bps int64, freeBelow Amount) Amount {
+	if a < freeBelow || a <= 0 || bps <= 0 {
+		return 0
+	}
+
+	// Split a to avoid overflowing a*bps for large inputs.
+	whole := a / 10000
+	rest := a % 10000
+
+	return whole*Amount(bps) + (rest*Amount(bps)+9999)/10000
+}
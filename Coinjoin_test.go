@@ -0,0 +1,101 @@
+package bitcoin
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStandardDenominations(t *testing.T) {
+	cases := []struct {
+		min      Amount
+		max      Amount
+		expected []Amount
+	}{
+		{1, 10, []Amount{1, 2, 3, 4, 5, 6, 8, 9, 10}},
+		{5000, 20000, []Amount{5000, 6561, 8192, 10000, 13122, 16384, 19683, 20000}},
+		{10, 1, nil},
+	}
+
+	for _, c := range cases {
+		result := StandardDenominations(c.min, c.max)
+
+		if !reflect.DeepEqual(result, c.expected) {
+			t.Errorf("StandardDenominations(%d, %d) -> %v, %v expected", c.min, c.max, result, c.expected)
+		}
+	}
+
+	all := StandardDenominations(5000*Satoshi, AllBTC)
+	if all[len(all)-1] > AllBTC {
+		t.Errorf("StandardDenominations() returned %d above max", all[len(all)-1])
+	}
+}
+
+func TestDecompose(t *testing.T) {
+	denominations := []Amount{10000, 50000, 20000, 100000}
+
+	cases := []struct {
+		in        Amount
+		outputs   []Amount
+		remainder Amount
+	}{
+		{0, nil, 0},
+		{9999, nil, 9999},
+		{100000, []Amount{100000}, 0},
+		{185000, []Amount{100000, 50000, 20000, 10000}, 5000},
+		{240000, []Amount{100000, 100000, 20000, 20000}, 0},
+	}
+
+	for _, c := range cases {
+		outputs, remainder := Decompose(c.in, denominations)
+
+		if !reflect.DeepEqual(outputs, c.outputs) || remainder != c.remainder {
+			t.Errorf("Decompose(%d) -> %v, %d, %v, %d expected", c.in, outputs, remainder, c.outputs, c.remainder)
+		}
+	}
+}
+
+func TestAnonymitySet(t *testing.T) {
+	outputs := []Amount{10000, 20000, 10000, 50000, 10000}
+
+	cases := []struct {
+		in       Amount
+		expected int
+	}{
+		{10000, 3},
+		{20000, 1},
+		{30000, 1},
+	}
+
+	for _, c := range cases {
+		result := AnonymitySet(c.in, outputs)
+
+		if result != c.expected {
+			t.Errorf("AnonymitySet(%d) -> %d, %d expected", c.in, result, c.expected)
+		}
+	}
+}
+
+func TestCoordinatorFee(t *testing.T) {
+	cases := []struct {
+		in        Amount
+		bps       int64
+		freeBelow Amount
+		expected  Amount
+	}{
+		{BTC, 30, 0, 300000},
+		{BTC, 0, 0, 0},
+		{10001, 30, 0, 31},
+		{10000, 30, 0, 30},
+		{MilliBTC, 30, 10 * MilliBTC, 0},
+		{10 * MilliBTC, 30, 10 * MilliBTC, 3000},
+		{AllBTC, 10000, 0, AllBTC},
+	}
+
+	for _, c := range cases {
+		result := CoordinatorFee(c.in, c.bps, c.freeBelow)
+
+		if result != c.expected {
+			t.Errorf("CoordinatorFee(%d, %d, %d) -> %d, %d expected", c.in, c.bps, c.freeBelow, result, c.expected)
+		}
+	}
+}
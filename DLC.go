@@ -0,0 +1,204 @@
+package bitcoin
+
+import (
+	"errors"
+	"math/big"
+)
+
+// PayoutPoint is a point on a DLC payout curve.
+type PayoutPoint struct {
+	// Outcome is the value attested by the oracle.
+	Outcome int64
+
+	// Payout is the amount paid to the offering party at Outcome.
+	Payout Amount
+}
+
+// PayoutCurve maps oracle outcomes to payouts for a discreet log
+// contract. Payouts between points are interpolated linearly.
+type PayoutCurve struct {
+	// Points must be ordered by strictly increasing Outcome.
+	Points []PayoutPoint
+
+	// Collateral is the total amount locked in the contract by
+	// both parties.
+	Collateral Amount
+
+	// Rounding is the granularity payouts are rounded to, for
+	// example 1000*Satoshi. Zero means one satoshi.
+	Rounding Amount
+}
+
+// CET describes a contract execution transaction covering a range
+// of outcomes with identical payouts.
+type CET struct {
+	// From and To are the first and last outcome covered.
+	From int64
+	To   int64
+
+	// Offer and Accept are the amounts paid to each party. They
+	// always sum to the collateral.
+	Offer  Amount
+	Accept Amount
+}
+
+// Validate checks that the curve is well-formed.
+func (c PayoutCurve) Validate() error {
+	if len(c.Points) < 2 {
+		return errors.New("payout curve needs at least two points")
+	}
+
+	if c.Collateral <= 0 {
+		return errors.New("collateral must be positive")
+	}
+
+	if c.Rounding < 0 {
+		return errors.New("rounding must not be negative")
+	}
+
+	for i, p := range c.Points {
+		if p.Payout < 0 || p.Payout > c.Collateral {
+			return errors.New("payout outside collateral")
+		}
+
+		if i > 0 && p.Outcome <= c.Points[i-1].Outcome {
+			return errors.New("payout points must have increasing outcomes")
+		}
+	}
+
+	return nil
+}
+
+// Payout returns the amounts paid to the offering and accepting
+// parties when the oracle attests to outcome. The offer payout is
+// rounded to the nearest multiple of Rounding and the accepting
+// party receives the rest of the collateral.
+func (c PayoutCurve) Payout(outcome int64) (Amount, Amount, error) {
+	err := c.Validate()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	first := c.Points[0]
+	last := c.Points[len(c.Points)-1]
+
+	if outcome < first.Outcome || outcome > last.Outcome {
+		return 0, 0, errors.New("outcome outside payout curve")
+	}
+
+	offer := c.interpolate(outcome)
+
+	return offer, c.Collateral - offer, nil
+}
+
+// CETs returns one CET per run of consecutive outcomes with equal
+// payouts. Payouts are monotonic within each segment of the curve,
+// so the end of each run is found by binary search; the cost grows
+// with the number of CETs and the logarithm of the outcome range,
+// not with the width of the range.
+func (c PayoutCurve) CETs() ([]CET, error) {
+	err := c.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var cets []CET
+
+	for i := 1; i < len(c.Points); i++ {
+		p0 := c.Points[i-1]
+		p1 := c.Points[i]
+
+		// The first outcome of a segment belongs to the previous
+		// one.
+		from := p0.Outcome
+		if i > 1 {
+			from++
+		}
+
+		for {
+			offer := c.segmentPayout(p0, p1, from)
+
+			// Find the last outcome in the segment paying offer,
+			// keeping payout(lo) == offer.
+			lo, hi := from, p1.Outcome
+			for lo < hi {
+				d := uint64(hi) - uint64(lo)
+				mid := lo + int64(d/2+d%2)
+
+				if c.segmentPayout(p0, p1, mid) == offer {
+					lo = mid
+				} else {
+					hi = mid - 1
+				}
+			}
+
+			if n := len(cets); n > 0 && cets[n-1].Offer == offer {
+				cets[n-1].To = lo
+			} else {
+				cets = append(cets, CET{
+					From:   from,
+					To:     lo,
+					Offer:  offer,
+					Accept: c.Collateral - offer,
+				})
+			}
+
+			if lo == p1.Outcome {
+				break
+			}
+
+			from = lo + 1
+		}
+	}
+
+	return cets, nil
+}
+
+// interpolate returns the rounded offer payout at outcome, which
+// must be inside the curve.
+func (c PayoutCurve) interpolate(outcome int64) Amount {
+	i := 1
+	for c.Points[i].Outcome < outcome {
+		i++
+	}
+
+	return c.segmentPayout(c.Points[i-1], c.Points[i], outcome)
+}
+
+// segmentPayout returns the rounded offer payout at outcome on the
+// segment from p0 to p1.
+func (c PayoutCurve) segmentPayout(p0, p1 PayoutPoint, outcome int64) Amount {
+	// p0.Payout + (p1.Payout-p0.Payout)*(outcome-p0.Outcome)/(p1.Outcome-p0.Outcome)
+	// using big integers so neither product nor rounding overflows.
+	num := new(big.Int).Mul(
+		big.NewInt(int64(p1.Payout-p0.Payout)),
+		new(big.Int).Sub(big.NewInt(outcome), big.NewInt(p0.Outcome)),
+	)
+	den := new(big.Int).Sub(big.NewInt(p1.Outcome), big.NewInt(p0.Outcome))
+
+	rounding := c.Rounding
+	if rounding == 0 {
+		rounding = Satoshi
+	}
+
+	// Round (p0.Payout + num/den) to the nearest multiple of
+	// rounding, halves rounding up.
+	num.Add(num, new(big.Int).Mul(big.NewInt(int64(p0.Payout)), den))
+	den.Mul(den, big.NewInt(int64(rounding)))
+	num.Mul(num, big.NewInt(2))
+	num.Add(num, den)
+	den.Mul(den, big.NewInt(2))
+
+	q := new(big.Int).Div(num, den)
+	payout := Amount(q.Int64()) * rounding
+
+	if payout > c.Collateral {
+		payout = c.Collateral
+	}
+
+	if payout < 0 {
+		payout = 0
+	}
+
+	return payout
+}
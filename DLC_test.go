@@ -0,0 +1,194 @@
+package bitcoin
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestPayout(t *testing.T) {
+	curve := PayoutCurve{
+		Points: []PayoutPoint{
+			{0, 0},
+			{10, 0},
+			{20, BTC},
+			{30, BTC},
+		},
+		Collateral: BTC,
+		Rounding:   1000 * Satoshi,
+	}
+
+	cases := []struct {
+		outcome int64
+		offer   Amount
+		accept  Amount
+		err     bool
+	}{
+		{-1, 0, 0, true},
+		{0, 0, BTC, false},
+		{10, 0, BTC, false},
+		{11, 100 * MilliBTC, 900 * MilliBTC, false},
+		{15, 500 * MilliBTC, 500 * MilliBTC, false},
+		{20, BTC, 0, false},
+		{30, BTC, 0, false},
+		{31, 0, 0, true},
+	}
+
+	for _, c := range cases {
+		offer, accept, err := curve.Payout(c.outcome)
+
+		if offer != c.offer || accept != c.accept || (err != nil) != c.err {
+			t.Errorf("Payout(%d) -> %d, %d, %v, %d, %d expected", c.outcome, offer, accept, err, c.offer, c.accept)
+		}
+	}
+}
+
+func TestPayoutRounding(t *testing.T) {
+	curve := PayoutCurve{
+		Points: []PayoutPoint{
+			{0, 0},
+			{3, 10000 * Satoshi},
+		},
+		Collateral: 10000 * Satoshi,
+	}
+
+	offer, _, _ := curve.Payout(1)
+	if offer != 3333*Satoshi {
+		t.Errorf("Payout(1) -> %d, %d expected", offer, 3333*Satoshi)
+	}
+
+	offer, _, _ = curve.Payout(2)
+	if offer != 6667*Satoshi {
+		t.Errorf("Payout(2) -> %d, %d expected", offer, 6667*Satoshi)
+	}
+
+	curve.Rounding = 1000 * Satoshi
+
+	offer, _, _ = curve.Payout(1)
+	if offer != 3000*Satoshi {
+		t.Errorf("Payout(1) -> %d, %d expected", offer, 3000*Satoshi)
+	}
+}
+
+func TestCETs(t *testing.T) {
+	curve := PayoutCurve{
+		Points: []PayoutPoint{
+			{0, 0},
+			{2, 0},
+			{4, 1000},
+			{6, 1000},
+		},
+		Collateral: 1000,
+		Rounding:   500,
+	}
+
+	cets, err := curve.CETs()
+	if err != nil {
+		t.Fatalf("CETs() failed: %s", err.Error())
+	}
+
+	expected := []CET{
+		{0, 2, 0, 1000},
+		{3, 3, 500, 500},
+		{4, 6, 1000, 0},
+	}
+
+	if !reflect.DeepEqual(cets, expected) {
+		t.Errorf("CETs() -> %v, %v expected", cets, expected)
+	}
+}
+
+func TestCETsMatchPayout(t *testing.T) {
+	curves := []PayoutCurve{
+		{Points: []PayoutPoint{{-5, 700}, {3, 0}, {9, 1000}, {20, 400}}, Collateral: 1000, Rounding: 100},
+		{Points: []PayoutPoint{{0, 0}, {7, 1000}}, Collateral: 1000, Rounding: 300},
+		{Points: []PayoutPoint{{0, 1000}, {4, 0}, {5, 0}, {50, 999}}, Collateral: 1000},
+	}
+
+	for i, curve := range curves {
+		cets, err := curve.CETs()
+		if err != nil {
+			t.Fatalf("case %d: CETs() failed: %s", i, err.Error())
+		}
+
+		// Every outcome must be covered in order by a CET paying what
+		// Payout pays, and neighbouring CETs must differ.
+		next := curve.Points[0].Outcome
+
+		for j, cet := range cets {
+			if cet.From != next || cet.To < cet.From {
+				t.Fatalf("case %d: CET %d covers %d-%d, %d expected first", i, j, cet.From, cet.To, next)
+			}
+
+			if j > 0 && cets[j-1].Offer == cet.Offer {
+				t.Errorf("case %d: CETs %d and %d both pay %d", i, j-1, j, cet.Offer)
+			}
+
+			for outcome := cet.From; outcome <= cet.To; outcome++ {
+				offer, accept, _ := curve.Payout(outcome)
+
+				if offer != cet.Offer || accept != cet.Accept {
+					t.Errorf("case %d: Payout(%d) -> %d, %d, CET pays %d, %d", i, outcome, offer, accept, cet.Offer, cet.Accept)
+				}
+			}
+
+			next = cet.To + 1
+		}
+
+		if last := curve.Points[len(curve.Points)-1].Outcome; next != last+1 {
+			t.Errorf("case %d: CETs end at %d, %d expected", i, next-1, last)
+		}
+	}
+}
+
+func TestCETsWideRange(t *testing.T) {
+	curve := PayoutCurve{
+		Points: []PayoutPoint{
+			{math.MinInt64, 0},
+			{0, 0},
+			{1e9, BTC},
+			{math.MaxInt64, BTC},
+		},
+		Collateral: BTC,
+		Rounding:   10 * MilliBTC,
+	}
+
+	cets, err := curve.CETs()
+	if err != nil {
+		t.Fatalf("CETs() failed: %s", err.Error())
+	}
+
+	if len(cets) != 101 {
+		t.Fatalf("CETs() -> %d CETs, 101 expected", len(cets))
+	}
+
+	first := CET{math.MinInt64, 5e6 - 1, 0, BTC}
+	last := CET{995e6, math.MaxInt64, BTC, 0}
+
+	if cets[0] != first || cets[100] != last {
+		t.Errorf("CETs() -> %v ... %v, %v ... %v expected", cets[0], cets[100], first, last)
+	}
+}
+
+func TestPayoutCurveValidate(t *testing.T) {
+	cases := []struct {
+		curve PayoutCurve
+		valid bool
+	}{
+		{PayoutCurve{Points: []PayoutPoint{{0, 0}, {1, 1}}, Collateral: 1}, true},
+		{PayoutCurve{Points: []PayoutPoint{{0, 0}}, Collateral: 1}, false},
+		{PayoutCurve{Points: []PayoutPoint{{0, 0}, {1, 1}}, Collateral: 0}, false},
+		{PayoutCurve{Points: []PayoutPoint{{0, 0}, {1, 2}}, Collateral: 1}, false},
+		{PayoutCurve{Points: []PayoutPoint{{0, 0}, {1, -1}}, Collateral: 1}, false},
+		{PayoutCurve{Points: []PayoutPoint{{1, 0}, {1, 1}}, Collateral: 1}, false},
+		{PayoutCurve{Points: []PayoutPoint{{0, 0}, {1, 1}}, Collateral: 1, Rounding: -1}, false},
+	}
+
+	for i, c := range cases {
+		err := c.curve.Validate()
+
+		if (err == nil) != c.valid {
+			t.Errorf("case %d: Validate() -> %v, valid=%v expected", i, err, c.valid)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package bitcoin
+
+// OutputType is a standard transaction output type.
+type OutputType int
+
+const (
+	// P2PKH is pay to public key hash, a legacy address.
+	P2PKH OutputType = iota + 1
+
+	// P2SH is pay to script hash.
+	P2SH
+
+	// P2WPKH is pay to witness public key hash, native SegWit v0.
+	P2WPKH
+
+	// P2WSH is pay to witness script hash, native SegWit v0.
+	P2WSH
+
+	// P2TR is pay to taproot, SegWit v1.
+	P2TR
+)
+
+// DefaultDustRelayFee is the default dust relay fee rate of Bitcoin
+// Core, 3 sat/vB.
+var DefaultDustRelayFee = SatPerVByte(3)
+
+// String implements fmt.Stringer.
+func (t OutputType) String() string {
+	switch t {
+	case P2PKH:
+		return "P2PKH"
+
+	case P2SH:
+		return "P2SH"
+
+	case P2WPKH:
+		return "P2WPKH"
+
+	case P2WSH:
+		return "P2WSH"
+
+	case P2TR:
+		return "P2TR"
+
+	default:
+		return "unknown"
+	}
+}
+
+// DustLimit returns the smallest output value of type t that is not
+// dust at feeRate, following Bitcoin Core's dust policy: an output is
+// dust if spending it would cost more than it is worth at the dust
+// relay fee. At DefaultDustRelayFee this is 546 satoshis for P2PKH
+// and 294 satoshis for P2WPKH. DustLimit returns 0 for unknown
+// output types.
+func DustLimit(t OutputType, feeRate FeeRate) Amount {
+	var script int64
+	witness := true
+
+	switch t {
+	case P2PKH:
+		script = 25
+		witness = false
+
+	case P2SH:
+		script = 23
+		witness = false
+
+	case P2WPKH:
+		script = 22
+
+	case P2WSH, P2TR:
+		script = 34
+
+	default:
+		return 0
+	}
+
+	// Value, script length and script.
+	size := 8 + 1 + script
+
+	// The input spending the output: outpoint, script length,
+	// sequence, and the signature script or its witness discount.
+	if witness {
+		size += 32 + 4 + 1 + 107/4 + 4
+	} else {
+		size += 32 + 4 + 1 + 107 + 4
+	}
+
+	return feeRate.FeeForVSize(size)
+}
+
+// IsDust reports whether an output of type t with value a is dust at
+// feeRate.
+func (a Amount) IsDust(t OutputType, feeRate FeeRate) bool {
+	return a < DustLimit(t, feeRate)
+}
@@ -0,0 +1,54 @@
+package bitcoin
+
+import (
+	"testing"
+)
+
+func TestDustLimit(t *testing.T) {
+	cases := []struct {
+		t        OutputType
+		rate     FeeRate
+		expected Amount
+	}{
+		{P2PKH, DefaultDustRelayFee, 546},
+		{P2SH, DefaultDustRelayFee, 540},
+		{P2WPKH, DefaultDustRelayFee, 294},
+		{P2WSH, DefaultDustRelayFee, 330},
+		{P2TR, DefaultDustRelayFee, 330},
+		{P2PKH, SatPerVByte(1), 182},
+		{P2WPKH, SatPerVByte(10), 980},
+		{P2WPKH, 0, 0},
+		{OutputType(0), DefaultDustRelayFee, 0},
+	}
+
+	for _, c := range cases {
+		result := DustLimit(c.t, c.rate)
+
+		if result != c.expected {
+			t.Errorf("DustLimit(%s, %s) -> %d, %d expected", c.t, c.rate, result, c.expected)
+		}
+	}
+}
+
+func TestIsDust(t *testing.T) {
+	cases := []struct {
+		in       Amount
+		t        OutputType
+		expected bool
+	}{
+		{545, P2PKH, true},
+		{546, P2PKH, false},
+		{293, P2WPKH, true},
+		{294, P2WPKH, false},
+		{0, P2TR, true},
+		{-1, P2TR, true},
+	}
+
+	for _, c := range cases {
+		result := c.in.IsDust(c.t, DefaultDustRelayFee)
+
+		if result != c.expected {
+			t.Errorf("%d.IsDust(%s) -> %v, %v expected", c.in, c.t, result, c.expected)
+		}
+	}
+}
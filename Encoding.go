@@ -0,0 +1,357 @@
+package bitcoin
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Value implements driver.Valuer. The amount is stored as an integer
+// number of satoshis, suitable for a BIGINT column.
+func (a Amount) Value() (driver.Value, error) {
+	return int64(a), nil
+}
+
+// Scan implements sql.Scanner. It accepts an integer number of
+// satoshis as int64, string or []byte.
+func (a *Amount) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case int64:
+		*a = Amount(v)
+
+	case string:
+		return a.scanString(v)
+
+	case []byte:
+		return a.scanString(string(v))
+
+	case nil:
+		return errors.New("cannot scan NULL into Amount")
+
+	default:
+		return fmt.Errorf("cannot scan %T into Amount", src)
+	}
+
+	return nil
+}
+
+func (a *Amount) scanString(s string) error {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	*a = Amount(v)
+
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The amount is
+// encoded as 8 byte little-endian satoshis, like output values in
+// Bitcoin's wire format.
+func (a Amount) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 8)
+	PutAmount(b, a)
+
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. data must
+// be exactly 8 bytes.
+func (a *Amount) UnmarshalBinary(data []byte) error {
+	if len(data) != 8 {
+		return errors.New("binary amount must be 8 bytes")
+	}
+
+	*a = Amount(binary.LittleEndian.Uint64(data))
+
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the MarshalBinary
+// encoding. Before MarshalBinary was added, gob encoded Amount as a
+// plain integer; such data cannot be decoded into an Amount.
+func (a Amount) GobEncode() ([]byte, error) {
+	return a.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder.
+func (a *Amount) GobDecode(data []byte) error {
+	return a.UnmarshalBinary(data)
+}
+
+// PutAmount encodes a into the first 8 bytes of b as little-endian
+// satoshis. It panics if b is too short.
+func PutAmount(b []byte, a Amount) {
+	binary.LittleEndian.PutUint64(b, uint64(a))
+}
+
+// ReadAmount reads an 8 byte little-endian amount from r.
+func ReadAmount(r io.Reader) (Amount, error) {
+	var b [8]byte
+
+	_, err := io.ReadFull(r, b[:])
+	if err != nil {
+		return 0, err
+	}
+
+	return Amount(binary.LittleEndian.Uint64(b[:])), nil
+}
+
+// WriteAmount writes a to w as 8 byte little-endian satoshis.
+func WriteAmount(w io.Writer, a Amount) error {
+	var b [8]byte
+	PutAmount(b[:], a)
+
+	_, err := w.Write(b[:])
+
+	return err
+}
+
+// SatoshiJSON is an Amount represented in JSON as an integer number
+// of satoshis, like 150000000 for 1.5 BTC. Convert with
+// SatoshiJSON(a) and Amount(s).
+type SatoshiJSON Amount
+
+// MarshalJSON implements json.Marshaler.
+func (s SatoshiJSON) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(s), 10), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Both numbers and
+// strings holding an integer number of satoshis are accepted.
+func (s *SatoshiJSON) UnmarshalJSON(in []byte) error {
+	if len(in) > 2 && in[len(in)-1] == '"' && in[0] == '"' {
+		in = in[1 : len(in)-1]
+	}
+
+	v, err := strconv.ParseInt(string(in), 10, 64)
+	if err != nil {
+		return err
+	}
+
+	*s = SatoshiJSON(v)
+
+	return nil
+}
+
+// BTCNumberJSON is an Amount represented in JSON as a number of
+// BTC, like 1.5, which is what Bitcoin Core RPC expects. The number
+// is written exactly, without going through float64.
+type BTCNumberJSON Amount
+
+// MarshalJSON implements json.Marshaler.
+func (b BTCNumberJSON) MarshalJSON() ([]byte, error) {
+	return Amount(b).MarshalText()
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts the same
+// input as Amount.UnmarshalJSON.
+func (b *BTCNumberJSON) UnmarshalJSON(in []byte) error {
+	return (*Amount)(b).UnmarshalJSON(in)
+}
+
+// Set implements flag.Value. It accepts anything ParseWithUnit
+// does, so both "--max-fee 0.0005" and "--max-fee '50000 sats'"
+// work.
+func (a *Amount) Set(s string) error {
+	v, err := ParseWithUnit(s)
+	if err != nil {
+		return err
+	}
+
+	*a = v
+
+	return nil
+}
+
+// Type implements pflag.Value.
+func (a *Amount) Type() string {
+	return "amount"
+}
+
+// MarshalYAML implements yaml.Marshaler from gopkg.in/yaml.v2 and
+// gopkg.in/yaml.v3, writing the amount as a number of BTC like
+// MarshalText. No yaml package is imported.
+func (a Amount) MarshalYAML() (interface{}, error) {
+	text, err := a.MarshalText()
+
+	return string(text), err
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler from gopkg.in/yaml.v2,
+// which gopkg.in/yaml.v3 also supports. Like Set, it accepts
+// anything ParseWithUnit does, so configuration files can say
+// "max-fee: 0.0005" or "max-fee: 50000 sats".
+func (a *Amount) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+
+	err := unmarshal(&s)
+	if err != nil {
+		return err
+	}
+
+	return a.Set(s)
+}
+
+// MarshalCBOR implements cbor.Marshaler from github.com/fxamacker/cbor.
+// The amount is encoded as a CBOR integer number of satoshis.
+func (a Amount) MarshalCBOR() ([]byte, error) {
+	major := byte(0)
+	n := uint64(a)
+
+	if a < 0 {
+		// Negative integers are encoded as -1-n.
+		major = 1 << 5
+		n = uint64(-(a + 1))
+	}
+
+	switch {
+	case n < 24:
+		return []byte{major | byte(n)}, nil
+
+	case n <= math.MaxUint8:
+		return []byte{major | 24, byte(n)}, nil
+
+	case n <= math.MaxUint16:
+		b := []byte{major | 25, 0, 0}
+		binary.BigEndian.PutUint16(b[1:], uint16(n))
+
+		return b, nil
+
+	case n <= math.MaxUint32:
+		b := []byte{major | 26, 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(b[1:], uint32(n))
+
+		return b, nil
+
+	default:
+		b := []byte{major | 27, 0, 0, 0, 0, 0, 0, 0, 0}
+		binary.BigEndian.PutUint64(b[1:], n)
+
+		return b, nil
+	}
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler from
+// github.com/fxamacker/cbor. data must be a single CBOR integer
+// number of satoshis. ErrOverflow is returned if it does not fit in
+// an Amount.
+func (a *Amount) UnmarshalCBOR(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("empty CBOR amount")
+	}
+
+	major := data[0] >> 5
+	info := data[0] & 0x1f
+
+	if major > 1 {
+		return fmt.Errorf("CBOR major type %d is not an integer", major)
+	}
+
+	var n uint64
+	size := 0
+
+	switch {
+	case info < 24:
+		n = uint64(info)
+
+	case info == 24:
+		size = 1
+
+	case info == 25:
+		size = 2
+
+	case info == 26:
+		size = 4
+
+	case info == 27:
+		size = 8
+
+	default:
+		return fmt.Errorf("invalid CBOR integer length %d", info)
+	}
+
+	if len(data) != 1+size {
+		return errors.New("CBOR amount must be a single integer")
+	}
+
+	for _, b := range data[1:] {
+		n = n<<8 | uint64(b)
+	}
+
+	if n > math.MaxInt64 {
+		return ErrOverflow
+	}
+
+	if major == 1 {
+		*a = -Amount(n) - 1
+	} else {
+		*a = Amount(n)
+	}
+
+	return nil
+}
+
+// MarshalGQL implements graphql.Marshaler from
+// github.com/99designs/gqlgen, writing the amount as a string of
+// BTC like MarshalText.
+func (a Amount) MarshalGQL(w io.Writer) {
+	text, _ := a.MarshalText()
+
+	_, _ = io.WriteString(w, strconv.Quote(string(text)))
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler from
+// github.com/99designs/gqlgen. Only strings are accepted, parsed as
+// BTC like UnmarshalText. Numbers are rejected, including integers:
+// a client sending 50000 likely means satoshis, and reading it as
+// BTC would move 50000 BTC.
+func (a *Amount) UnmarshalGQL(v interface{}) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("cannot unmarshal %T into Amount, use a string of BTC", v)
+	}
+
+	return a.UnmarshalText([]byte(s))
+}
+
+// MarshalXML implements xml.Marshaler, writing the amount as a
+// number of BTC like MarshalText.
+func (a Amount) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	text, _ := a.MarshalText()
+
+	return e.EncodeElement(string(text), start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Surrounding whitespace
+// is ignored.
+func (a *Amount) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+
+	err := d.DecodeElement(&s, &start)
+	if err != nil {
+		return err
+	}
+
+	return a.UnmarshalText([]byte(strings.TrimSpace(s)))
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (a Amount) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	text, _ := a.MarshalText()
+
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr. Surrounding
+// whitespace is ignored.
+func (a *Amount) UnmarshalXMLAttr(attr xml.Attr) error {
+	return a.UnmarshalText([]byte(strings.TrimSpace(attr.Value)))
+}
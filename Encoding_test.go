@@ -0,0 +1,449 @@
+package bitcoin
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"io"
+	"io/ioutil"
+	"math"
+	"reflect"
+	"testing"
+)
+
+var (
+	_ sql.Scanner                = (*Amount)(nil)
+	_ driver.Valuer              = Amount(0)
+	_ encoding.BinaryMarshaler   = Amount(0)
+	_ encoding.BinaryUnmarshaler = (*Amount)(nil)
+	_ flag.Value                 = (*Amount)(nil)
+	_ gob.GobEncoder             = Amount(0)
+	_ gob.GobDecoder             = (*Amount)(nil)
+	_ xml.Marshaler              = Amount(0)
+	_ xml.Unmarshaler            = (*Amount)(nil)
+	_ xml.MarshalerAttr          = Amount(0)
+	_ xml.UnmarshalerAttr        = (*Amount)(nil)
+)
+
+func TestValue(t *testing.T) {
+	cases := []Amount{0, Satoshi, BTC, -BTC, math.MaxInt64, math.MinInt64}
+
+	for _, c := range cases {
+		result, err := c.Value()
+
+		if result != int64(c) || err != nil {
+			t.Errorf("%d.Value() -> %v, %v, %d expected", c, result, err, int64(c))
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	cases := []struct {
+		in       interface{}
+		expected Amount
+		err      bool
+	}{
+		{int64(0), 0, false},
+		{int64(12345678), 12345678 * Satoshi, false},
+		{int64(-1), -Satoshi, false},
+		{"2100", 2100 * Satoshi, false},
+		{"-2100", -2100 * Satoshi, false},
+		{[]byte("9223372036854775807"), math.MaxInt64, false},
+		{[]byte("9223372036854775808"), 0, true},
+		{"0.5", 0, true},
+		{"", 0, true},
+		{nil, 0, true},
+		{1.5, 0, true},
+	}
+
+	for _, c := range cases {
+		var result Amount
+		err := result.Scan(c.in)
+
+		if result != c.expected || (err != nil) != c.err {
+			t.Errorf("Scan(%#v) -> %d, %v, %d expected", c.in, result, err, c.expected)
+		}
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	cases := []struct {
+		in       Amount
+		expected string
+	}{
+		{0, "0000000000000000"},
+		{Satoshi, "0100000000000000"},
+		{BTC, "00e1f50500000000"},
+		{-Satoshi, "ffffffffffffffff"},
+		{math.MaxInt64, "ffffffffffffff7f"},
+	}
+
+	for _, c := range cases {
+		result, err := c.in.MarshalBinary()
+
+		if hex.EncodeToString(result) != c.expected || err != nil {
+			t.Errorf("%d.MarshalBinary() -> %x, %v, %s expected", c.in, result, err, c.expected)
+		}
+
+		var decoded Amount
+		err = decoded.UnmarshalBinary(result)
+
+		if decoded != c.in || err != nil {
+			t.Errorf("UnmarshalBinary(%x) -> %d, %v, %d expected", result, decoded, err, c.in)
+		}
+
+		var buf bytes.Buffer
+
+		err = WriteAmount(&buf, c.in)
+		if hex.EncodeToString(buf.Bytes()) != c.expected || err != nil {
+			t.Errorf("WriteAmount(%d) wrote %x, %v, %s expected", c.in, buf.Bytes(), err, c.expected)
+		}
+
+		read, err := ReadAmount(&buf)
+		if read != c.in || err != nil {
+			t.Errorf("ReadAmount(%s) -> %d, %v, %d expected", c.expected, read, err, c.in)
+		}
+	}
+}
+
+func TestUnmarshalBinaryLength(t *testing.T) {
+	var a Amount
+
+	for _, in := range [][]byte{nil, make([]byte, 7), make([]byte, 9)} {
+		if a.UnmarshalBinary(in) == nil {
+			t.Errorf("UnmarshalBinary(%x) accepted %d bytes", in, len(in))
+		}
+	}
+}
+
+func TestReadAmountShort(t *testing.T) {
+	_, err := ReadAmount(bytes.NewReader([]byte{1, 2, 3}))
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("ReadAmount() on 3 bytes -> %v, %v expected", err, io.ErrUnexpectedEOF)
+	}
+
+	_, err = ReadAmount(bytes.NewReader(nil))
+	if err != io.EOF {
+		t.Errorf("ReadAmount() on no bytes -> %v, %v expected", err, io.EOF)
+	}
+}
+
+func TestJSONRepresentations(t *testing.T) {
+	type payload struct {
+		Default Amount        `json:"default"`
+		Sats    SatoshiJSON   `json:"sats"`
+		Core    BTCNumberJSON `json:"core"`
+	}
+
+	cases := []struct {
+		in       Amount
+		expected string
+	}{
+		{0, `{"default":"0.0","sats":0,"core":0.0}`},
+		{BTC + 500*MilliBTC, `{"default":"1.5","sats":150000000,"core":1.5}`},
+		{-Satoshi, `{"default":"-0.00000001","sats":-1,"core":-0.00000001}`},
+		{AllBTC, `{"default":"20999999.9769","sats":2099999997690000,"core":20999999.9769}`},
+	}
+
+	for _, c := range cases {
+		p := payload{c.in, SatoshiJSON(c.in), BTCNumberJSON(c.in)}
+
+		result, err := json.Marshal(p)
+		if string(result) != c.expected || err != nil {
+			t.Errorf("json.Marshal(%d) -> %s, %v, %s expected", c.in, result, err, c.expected)
+		}
+	}
+
+	var p payload
+
+	err := json.Unmarshal([]byte(`{"default":"1.5","sats":"150000000","core":1.5}`), &p)
+	if err != nil || p.Default != BTC+500*MilliBTC || Amount(p.Sats) != BTC+500*MilliBTC || Amount(p.Core) != BTC+500*MilliBTC {
+		t.Errorf("json.Unmarshal() -> %+v, %v", p, err)
+	}
+
+	err = json.Unmarshal([]byte(`{"sats":1.5}`), &p)
+	if err == nil {
+		t.Errorf("json.Unmarshal() accepted fractional satoshis")
+	}
+}
+
+func TestFlag(t *testing.T) {
+	cases := []struct {
+		args     []string
+		expected Amount
+		err      bool
+	}{
+		{nil, 10 * MilliBTC, false},
+		{[]string{"--max-fee", "0.0005"}, 50000 * Satoshi, false},
+		{[]string{"--max-fee=50000 sats"}, 50000 * Satoshi, false},
+		{[]string{"--max-fee", "2 mBTC"}, 2 * MilliBTC, false},
+		{[]string{"--max-fee", "lots"}, 10 * MilliBTC, true},
+	}
+
+	for _, c := range cases {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(ioutil.Discard)
+
+		maxFee := 10 * MilliBTC
+		fs.Var(&maxFee, "max-fee", "maximum fee")
+
+		err := fs.Parse(c.args)
+
+		if maxFee != c.expected || (err != nil) != c.err {
+			t.Errorf("Parse(%v) -> %d, %v, %d expected", c.args, maxFee, err, c.expected)
+		}
+
+		if defValue := fs.Lookup("max-fee").DefValue; defValue != "10 mBTC" {
+			t.Errorf("DefValue is '%s', '10 mBTC' expected", defValue)
+		}
+	}
+
+	var a Amount
+	if a.Type() != "amount" {
+		t.Errorf("Type() -> '%s', 'amount' expected", a.Type())
+	}
+}
+
+func TestYAML(t *testing.T) {
+	cases := []struct {
+		in       interface{}
+		expected Amount
+		err      bool
+	}{
+		{"0.0005", 50000, false},
+		{"50000 sats", 50000, false},
+		{"1.5 mBTC", 150000, false},
+		{"-1", -BTC, false},
+		{"lots", 0, true},
+		{42, 0, true},
+	}
+
+	for _, c := range cases {
+		// unmarshal mimics the function yaml packages pass, which
+		// decodes scalars into strings.
+		unmarshal := func(v interface{}) error {
+			s, ok := c.in.(string)
+			if !ok {
+				return errors.New("not a string")
+			}
+
+			*v.(*string) = s
+
+			return nil
+		}
+
+		var result Amount
+
+		err := result.UnmarshalYAML(unmarshal)
+		if result != c.expected || (err != nil) != c.err {
+			t.Errorf("UnmarshalYAML(%v) -> %d, %v, %d expected", c.in, result, err, c.expected)
+		}
+	}
+
+	for _, a := range []Amount{0, Satoshi, -BTC, AllBTC, math.MinInt64} {
+		out, err := a.MarshalYAML()
+		if err != nil {
+			t.Fatalf("MarshalYAML() failed: %s", err)
+		}
+
+		var back Amount
+
+		err = back.UnmarshalYAML(func(v interface{}) error {
+			*v.(*string) = out.(string)
+
+			return nil
+		})
+		if back != a || err != nil {
+			t.Errorf("UnmarshalYAML(%v) -> %d, %v, %d expected", out, back, err, a)
+		}
+	}
+}
+
+func TestCBOR(t *testing.T) {
+	// Vectors from RFC 8949 appendix A.
+	cases := []struct {
+		in       Amount
+		expected string
+	}{
+		{0, "00"},
+		{1, "01"},
+		{10, "0a"},
+		{23, "17"},
+		{24, "1818"},
+		{100, "1864"},
+		{1000, "1903e8"},
+		{1000000, "1a000f4240"},
+		{1000000000000, "1b000000e8d4a51000"},
+		{-1, "20"},
+		{-10, "29"},
+		{-100, "3863"},
+		{-1000, "3903e7"},
+		{math.MaxInt64, "1b7fffffffffffffff"},
+		{math.MinInt64, "3b7fffffffffffffff"},
+	}
+
+	for _, c := range cases {
+		out, err := c.in.MarshalCBOR()
+		if hex.EncodeToString(out) != c.expected || err != nil {
+			t.Errorf("%d.MarshalCBOR() -> %x, %v, %s expected", c.in, out, err, c.expected)
+		}
+
+		var back Amount
+
+		err = back.UnmarshalCBOR(out)
+		if back != c.in || err != nil {
+			t.Errorf("UnmarshalCBOR(%x) -> %d, %v, %d expected", out, back, err, c.in)
+		}
+	}
+
+	invalid := []string{"", "1bffffffffffffffff", "3b8000000000000000", "40", "1903", "0000", "1c"}
+
+	for _, in := range invalid {
+		data, _ := hex.DecodeString(in)
+
+		var a Amount
+
+		err := a.UnmarshalCBOR(data)
+		if err == nil {
+			t.Errorf("UnmarshalCBOR(%s) -> %d, error expected", in, a)
+		}
+	}
+
+	// Non-minimal encodings are valid CBOR.
+	var a Amount
+
+	err := a.UnmarshalCBOR([]byte{0x19, 0x00, 0x01})
+	if a != 1 || err != nil {
+		t.Errorf("UnmarshalCBOR(190001) -> %d, %v, 1 expected", a, err)
+	}
+}
+
+func TestMarshalGQL(t *testing.T) {
+	cases := []struct {
+		in       Amount
+		expected string
+	}{
+		{0, `"0.0"`},
+		{BTC + 500*MilliBTC, `"1.5"`},
+		{-Satoshi, `"-0.00000001"`},
+	}
+
+	for _, c := range cases {
+		var out bytes.Buffer
+
+		c.in.MarshalGQL(&out)
+
+		if out.String() != c.expected {
+			t.Errorf("%d.MarshalGQL() -> %s, %s expected", c.in, out.String(), c.expected)
+		}
+	}
+}
+
+func TestUnmarshalGQL(t *testing.T) {
+	cases := []struct {
+		in       interface{}
+		expected Amount
+		err      bool
+	}{
+		{"1.5", BTC + 500*MilliBTC, false},
+		{"-0.00000001", -Satoshi, false},
+		{json.Number("0.5"), 0, true},
+		{json.Number("50000"), 0, true},
+		{50000, 0, true},
+		{int64(50000), 0, true},
+		{1.5, 0, true},
+		{"1.5 BTC", 0, true},
+		{nil, 0, true},
+	}
+
+	for _, c := range cases {
+		var result Amount
+
+		err := result.UnmarshalGQL(c.in)
+		if result != c.expected || (err != nil) != c.err {
+			t.Errorf("UnmarshalGQL(%#v) -> %d, %v, %d expected", c.in, result, err, c.expected)
+		}
+	}
+}
+
+func TestGob(t *testing.T) {
+	type payment struct {
+		ID     int
+		Amount Amount
+		Fees   []Amount
+	}
+
+	in := payment{7, AllBTC, []Amount{0, -Satoshi, math.MinInt64, math.MaxInt64}}
+
+	var buf bytes.Buffer
+
+	err := gob.NewEncoder(&buf).Encode(in)
+	if err != nil {
+		t.Fatalf("Encode() failed: %s", err)
+	}
+
+	var out payment
+
+	err = gob.NewDecoder(&buf).Decode(&out)
+	if !reflect.DeepEqual(in, out) || err != nil {
+		t.Errorf("Decode() -> %+v, %v, %+v expected", out, err, in)
+	}
+
+	var a Amount
+
+	if a.GobDecode([]byte{1, 2, 3}) == nil {
+		t.Errorf("GobDecode() accepted 3 bytes")
+	}
+}
+
+func TestXML(t *testing.T) {
+	type payment struct {
+		XMLName xml.Name `xml:"payment"`
+		Fee     Amount   `xml:"fee,attr"`
+		Amount  Amount   `xml:"amount"`
+	}
+
+	cases := []struct {
+		in       payment
+		expected string
+	}{
+		{payment{Fee: 1250, Amount: BTC + 500*MilliBTC}, `<payment fee="0.0000125"><amount>1.5</amount></payment>`},
+		{payment{Fee: 0, Amount: -Satoshi}, `<payment fee="0.0"><amount>-0.00000001</amount></payment>`},
+		{payment{Fee: math.MaxInt64, Amount: math.MinInt64}, `<payment fee="92233720368.54775807"><amount>-92233720368.54775808</amount></payment>`},
+	}
+
+	for _, c := range cases {
+		out, err := xml.Marshal(c.in)
+		if string(out) != c.expected || err != nil {
+			t.Errorf("xml.Marshal(%+v) -> %s, %v, %s expected", c.in, out, err, c.expected)
+		}
+
+		var back payment
+
+		err = xml.Unmarshal(out, &back)
+		if back.Fee != c.in.Fee || back.Amount != c.in.Amount || err != nil {
+			t.Errorf("xml.Unmarshal(%s) -> %+v, %v, %+v expected", out, back, err, c.in)
+		}
+	}
+
+	var p payment
+
+	err := xml.Unmarshal([]byte("<payment fee=\" 0.001 \"><amount>\n  1.5\n</amount></payment>"), &p)
+	if p.Fee != 100000 || p.Amount != BTC+500*MilliBTC || err != nil {
+		t.Errorf("xml.Unmarshal() with whitespace -> %+v, %v", p, err)
+	}
+
+	for _, in := range []string{`<payment fee="lots"></payment>`, `<payment><amount>1.5 BTC</amount></payment>`} {
+		err := xml.Unmarshal([]byte(in), &p)
+		if err == nil {
+			t.Errorf("xml.Unmarshal(%s) -> %+v, error expected", in, p)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package bitcoin
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Match is an amount found in free text by FindAmounts.
+type Match struct {
+	// Amount is the value found.
+	Amount Amount
+
+	// Text is the part of the input the amount was read from.
+	Text string
+
+	// Confidence is a score between 0 and 1 describing how likely
+	// it is that Text really denotes a bitcoin amount.
+	Confidence float64
+}
+
+var amountPattern = regexp.MustCompile(`(?i)(?:(₿|btc|xbt)\s?)?([+-]?\d+(?:[.,']\d+)*)(?:\s?((?:mbtc|btc|xbt|satoshis|satoshi|sats|sat)\b|₿))?`)
+
+// FindAmounts finds bitcoin amounts in arbitrary text such as chat
+// messages or emails. Numbers carrying a unit like "0.5 BTC",
+// "₿0.5" or "1,500 sats" are found with high confidence. Decimal
+// numbers without a unit are reported with low confidence, and
+// bare integers without a unit are ignored. In a range like
+// "2-3 BTC" only the amount carrying the unit, 3 BTC, is found.
+func FindAmounts(text string) []Match {
+	var matches []Match
+
+	for _, loc := range amountPattern.FindAllStringSubmatchIndex(text, -1) {
+		start := loc[0]
+		number := text[loc[4]:loc[5]]
+
+		// Skip numbers glued to a word, like "abc123". A hyphen
+		// between numbers is a range like "2-3 BTC" rather than a
+		// sign, so the amount after it is read.
+		if r, _ := utf8.DecodeLastRuneInString(text[:start]); start > 0 && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if !unicode.IsDigit(r) || loc[4] != start || number[0] != '-' {
+				continue
+			}
+
+			start++
+			number = number[1:]
+		}
+
+		unit := ""
+		switch {
+		case loc[2] >= 0:
+			unit = strings.ToLower(text[loc[2]:loc[3]])
+
+		case loc[6] >= 0:
+			unit = strings.ToLower(text[loc[6]:loc[7]])
+		}
+
+		m, ok := readMatch(number, unit)
+		if !ok {
+			continue
+		}
+
+		m.Text = text[start:loc[1]]
+		matches = append(matches, m)
+	}
+
+	return matches
+}
+
+// ExtractAmounts returns the amounts found by FindAmounts, in the
+// order they appear in text.
+func ExtractAmounts(text string) []Amount {
+	matches := FindAmounts(text)
+	amounts := make([]Amount, len(matches))
+
+	for i, m := range matches {
+		amounts[i] = m.Amount
+	}
+
+	return amounts
+}
+
+func readMatch(number string, unit string) (Match, bool) {
+	switch unit {
+	case "sat", "sats", "satoshi", "satoshis":
+		// Satoshis are whole numbers, so a separator can only be
+		// grouping, and must be followed by three digits. "1,5 sats"
+		// is not a sat count.
+		groups := strings.FieldsFunc(number, func(r rune) bool {
+			return r == ',' || r == '.' || r == '\''
+		})
+
+		for _, g := range groups[1:] {
+			if len(g) != 3 {
+				return Match{}, false
+			}
+		}
+
+		clean := strings.Join(groups, "")
+
+		value, err := strconv.ParseInt(clean, 10, 64)
+		if err != nil {
+			return Match{}, false
+		}
+
+		return Match{Amount: Amount(value), Confidence: 0.9}, true
+	}
+
+	clean, warnings := SanitizeInput(number)
+
+	value, err := Parse(clean)
+	if err != nil {
+		return Match{}, false
+	}
+
+	m := Match{Amount: value, Confidence: 0.9}
+
+	switch unit {
+	case "":
+		if !strings.Contains(clean, ".") {
+			return Match{}, false
+		}
+
+		m.Confidence = 0.3
+
+	case "mbtc":
+		if value%(BTC/MilliBTC) != 0 {
+			// More than five decimals would be sub-satoshi.
+			return Match{}, false
+		}
+
+		m.Amount = value / (BTC / MilliBTC)
+	}
+
+	for _, w := range warnings {
+		if w == WarningAmbiguous {
+			m.Confidence /= 2
+		}
+	}
+
+	return m, true
+}
@@ -0,0 +1,61 @@
+package bitcoin
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestFindAmounts(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected []Match
+	}{
+		{"", nil},
+		{"no amounts here", nil},
+		{"send 0.5 BTC please", []Match{{500 * MilliBTC, "0.5 BTC", 0.9}}},
+		{"I paid ₿0.001 yesterday", []Match{{MilliBTC, "₿0.001", 0.9}}},
+		{"that's 0.25₿", []Match{{250 * MilliBTC, "0.25₿", 0.9}}},
+		{"tip 1,500 sats", []Match{{1500 * Satoshi, "1,500 sats", 0.9}}},
+		{"tip 21 sat!", []Match{{21 * Satoshi, "21 sat", 0.9}}},
+		{"sent 100000000000 sats", []Match{{1000 * BTC, "100000000000 sats", 0.9}}},
+		{"sent 9,223,372,036,854,775,807 sats", []Match{{math.MaxInt64, "9,223,372,036,854,775,807 sats", 0.9}}},
+		{"sent 9223372036854775808 sats", nil},
+		{"1,5 sats", nil},
+		{"1.50 sats", nil},
+		{"1'500'000 sats", []Match{{1500000 * Satoshi, "1'500'000 sats", 0.9}}},
+		{"2-3 BTC", []Match{{3 * BTC, "3 BTC", 0.9}}},
+		{"0.2-0.3 BTC", []Match{
+			{200 * MilliBTC, "0.2", 0.3},
+			{300 * MilliBTC, "0.3 BTC", 0.9},
+		}},
+		{"refund -0.5 BTC", []Match{{-500 * MilliBTC, "-0.5 BTC", 0.9}}},
+		{"1.5 mBTC", []Match{{1500 * MicroBTC, "1.5 mBTC", 0.9}}},
+		{"0.000001 mBTC", nil},
+		{"maybe 0.02?", []Match{{20 * MilliBTC, "0.02", 0.3}}},
+		{"room 42", nil},
+		{"abc1.5 BTC", nil},
+		{"1,500 BTC", []Match{{BTC + 500*MilliBTC, "1,500 BTC", 0.45}}},
+		{"0.1 BTC and 2000 sats", []Match{
+			{100 * MilliBTC, "0.1 BTC", 0.9},
+			{2000 * Satoshi, "2000 sats", 0.9},
+		}},
+	}
+
+	for _, c := range cases {
+		result := FindAmounts(c.in)
+
+		if !reflect.DeepEqual(result, c.expected) {
+			t.Errorf("FindAmounts('%s') -> %v, %v expected", c.in, result, c.expected)
+		}
+	}
+}
+
+func TestExtractAmounts(t *testing.T) {
+	result := ExtractAmounts("0.1 BTC and 2000 sats")
+	expected := []Amount{100 * MilliBTC, 2000 * Satoshi}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ExtractAmounts() -> %v, %v expected", result, expected)
+	}
+}
@@ -0,0 +1,123 @@
+package bitcoin
+
+import (
+	"errors"
+	"math/big"
+)
+
+// FXRate is an exchange rate between two currencies. A rate from
+// "EUR" to "DKK" with Value 74603 and 4 Decimals means one EUR costs
+// 7.4603 DKK.
+type FXRate struct {
+	// From is the currency being converted from.
+	From string
+
+	// To is the currency being converted to.
+	To string
+
+	// Value is the price of one unit of From in units of
+	// 10^-Decimals of To.
+	Value int64
+
+	// Decimals is the number of decimals in Value.
+	Decimals int
+}
+
+// Leg is one step of a conversion made by FiatValueVia. It records
+// the rate used and the value after the conversion.
+type Leg struct {
+	// Rate is the rate used for the conversion.
+	Rate FXRate
+
+	// Value is the value after the conversion.
+	Value Fiat
+}
+
+// String implements fmt.Stringer. The rate is returned like
+// "7.4603 DKK/EUR".
+func (r FXRate) String() string {
+	return formatFixed(r.Value, r.Decimals) + " " + r.To + "/" + r.From
+}
+
+// FXRate returns p as a rate from "BTC" to p.Currency.
+func (p Price) FXRate() FXRate {
+	return FXRate{From: "BTC", To: p.Currency, Value: p.Value, Decimals: p.Decimals}
+}
+
+// FiatValueVia values a in a currency not quoted directly, by
+// converting to p.Currency using p and then through each of rates
+// in turn. Every leg's value keeps p.Decimals decimals.
+// If roundLegs is true, the value is rounded using mode after every
+// leg, as if each conversion was actually executed. Otherwise the
+// exact value is carried through and only the reported values are
+// rounded. The returned legs document how the final value was
+// reached; the last leg holds the final value.
+func (a Amount) FiatValueVia(p Price, rates []FXRate, roundLegs bool, mode RoundingMode) (Fiat, []Leg, error) {
+	if p.Value <= 0 || p.Decimals < 0 {
+		return Fiat{}, nil, errors.New("invalid price")
+	}
+
+	decimals := p.Decimals
+
+	// exact is the value in whole units of the current currency.
+	exact := new(big.Rat).SetFrac(
+		new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(p.Value)),
+		new(big.Int).Mul(big.NewInt(int64(BTC)), pow10(p.Decimals)),
+	)
+
+	var legs []Leg
+
+	leg := func(rate FXRate) error {
+		value, err := roundRat(exact, decimals, mode)
+		if err != nil {
+			return err
+		}
+
+		if roundLegs {
+			exact.SetFrac(big.NewInt(value), pow10(decimals))
+		}
+
+		legs = append(legs, Leg{
+			Rate:  rate,
+			Value: Fiat{Currency: rate.To, Value: value, Decimals: decimals},
+		})
+
+		return nil
+	}
+
+	err := leg(p.FXRate())
+	if err != nil {
+		return Fiat{}, nil, err
+	}
+
+	for _, rate := range rates {
+		if rate.From != legs[len(legs)-1].Rate.To {
+			return Fiat{}, nil, errors.New("rate " + rate.String() + " does not continue from " + legs[len(legs)-1].Rate.To)
+		}
+
+		if rate.Value <= 0 || rate.Decimals < 0 {
+			return Fiat{}, nil, errors.New("invalid rate " + rate.String())
+		}
+
+		exact.Mul(exact, new(big.Rat).SetFrac(big.NewInt(rate.Value), pow10(rate.Decimals)))
+
+		err = leg(rate)
+		if err != nil {
+			return Fiat{}, nil, err
+		}
+	}
+
+	return legs[len(legs)-1].Value, legs, nil
+}
+
+// roundRat returns x in units of 10^-decimals, rounded using mode.
+func roundRat(x *big.Rat, decimals int, mode RoundingMode) (int64, error) {
+	num := new(big.Int).Mul(x.Num(), pow10(decimals))
+	value := bigDivRound(num, x.Denom(), mode)
+
+	if !value.IsInt64() {
+		return 0, ErrOverflow
+	}
+
+	return value.Int64(), nil
+}
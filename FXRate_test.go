@@ -0,0 +1,81 @@
+package bitcoin
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFiatValueVia(t *testing.T) {
+	eur := Price{"EUR", 2500000, 2}
+	dkk := FXRate{"EUR", "DKK", 74603, 4}
+	sek := FXRate{"DKK", "SEK", 15555, 4}
+
+	value, legs, err := BTC.FiatValueVia(eur, []FXRate{dkk}, false, RoundHalfUp)
+	if err != nil {
+		t.Fatalf("FiatValueVia() failed: %s", err.Error())
+	}
+
+	if value != (Fiat{"DKK", 18650750, 2}) {
+		t.Errorf("FiatValueVia() -> %s, 186507.50 DKK expected", value)
+	}
+
+	expected := []Leg{
+		{FXRate{"BTC", "EUR", 2500000, 2}, Fiat{"EUR", 2500000, 2}},
+		{dkk, Fiat{"DKK", 18650750, 2}},
+	}
+
+	if !reflect.DeepEqual(legs, expected) {
+		t.Errorf("FiatValueVia() legs -> %v, %v expected", legs, expected)
+	}
+
+	// Small amounts show how rounding every leg compounds, while
+	// carrying the exact value does not.
+	cases := []struct {
+		in        Amount
+		roundLegs bool
+		expected  Fiat
+	}{
+		{100 * Satoshi, false, Fiat{"SEK", 29, 2}},
+		{100 * Satoshi, true, Fiat{"SEK", 34, 2}},
+		{30 * Satoshi, false, Fiat{"SEK", 9, 2}},
+		{30 * Satoshi, true, Fiat{"SEK", 11, 2}},
+	}
+
+	for _, c := range cases {
+		result, _, err := c.in.FiatValueVia(eur, []FXRate{dkk, sek}, c.roundLegs, RoundHalfUp)
+
+		if result != c.expected || err != nil {
+			t.Errorf("%d.FiatValueVia(roundLegs=%v) -> %s, %v, %s expected", c.in, c.roundLegs, result, err, c.expected)
+		}
+	}
+}
+
+func TestFiatValueViaErrors(t *testing.T) {
+	eur := Price{"EUR", 2500000, 2}
+
+	cases := []struct {
+		p     Price
+		rates []FXRate
+	}{
+		{Price{"EUR", 0, 2}, nil},
+		{eur, []FXRate{{"USD", "DKK", 74603, 4}}},
+		{eur, []FXRate{{"EUR", "DKK", 0, 4}}},
+		{eur, []FXRate{{"EUR", "DKK", 1, -1}}},
+	}
+
+	for _, c := range cases {
+		_, _, err := BTC.FiatValueVia(c.p, c.rates, false, RoundHalfUp)
+
+		if err == nil {
+			t.Errorf("FiatValueVia(%s, %v) should fail", c.p, c.rates)
+		}
+	}
+}
+
+func TestFXRateString(t *testing.T) {
+	r := FXRate{"EUR", "DKK", 74603, 4}
+
+	if r.String() != "7.4603 DKK/EUR" {
+		t.Errorf("String() -> '%s', '7.4603 DKK/EUR' expected", r.String())
+	}
+}
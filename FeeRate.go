@@ -0,0 +1,76 @@
+package bitcoin
+
+import (
+	"math"
+	"strconv"
+)
+
+// FeeRate is a fee rate in satoshis per 1000 virtual bytes, which is
+// the unit Bitcoin Core uses internally. It can represent rates in
+// sat/vB with three decimals and any integer rate in sat/kWU
+// exactly.
+// Will typically be created using SatPerVByte or SatPerKWU.
+type FeeRate int64
+
+// FeeRateUnit selects the unit used by FeeRate.Format.
+type FeeRateUnit int
+
+const (
+	// PerVByte is satoshis per virtual byte.
+	PerVByte FeeRateUnit = iota
+
+	// PerKWU is satoshis per 1000 weight units.
+	PerKWU
+)
+
+// SatPerVByte returns a fee rate of rate satoshis per virtual byte,
+// rounded to the nearest 0.001 sat/vB.
+func SatPerVByte(rate float64) FeeRate {
+	return FeeRate(math.Round(rate * 1000))
+}
+
+// SatPerKWU returns a fee rate of rate satoshis per 1000 weight
+// units, rounded to the nearest 0.25 sat/kWU.
+func SatPerKWU(rate float64) FeeRate {
+	return FeeRate(math.Round(rate * 4))
+}
+
+// SatPerVByte returns the fee rate in satoshis per virtual byte.
+func (r FeeRate) SatPerVByte() float64 {
+	return float64(r) / 1000
+}
+
+// SatPerKWU returns the fee rate in satoshis per 1000 weight units.
+func (r FeeRate) SatPerKWU() float64 {
+	return float64(r) / 4
+}
+
+// FeeForVSize returns the fee for a transaction of vsize virtual
+// bytes. The fee is rounded up so the rate is always met.
+func (r FeeRate) FeeForVSize(vsize int64) Amount {
+	return divRound(Amount(r)*Amount(vsize), 1000, RoundCeil)
+}
+
+// FeeForWeight returns the fee for a transaction of weight weight
+// units. Like Bitcoin Core, the weight is first rounded up to whole
+// virtual bytes.
+func (r FeeRate) FeeForWeight(weight int64) Amount {
+	return r.FeeForVSize((weight + 3) / 4)
+}
+
+// Format returns the fee rate in unit, like "8.3 sat/vB" or
+// "2075 sat/kWU".
+func (r FeeRate) Format(unit FeeRateUnit) string {
+	switch unit {
+	case PerKWU:
+		return strconv.FormatFloat(r.SatPerKWU(), 'f', -1, 64) + " sat/kWU"
+
+	default:
+		return strconv.FormatFloat(r.SatPerVByte(), 'f', -1, 64) + " sat/vB"
+	}
+}
+
+// String implements fmt.Stringer. The rate is returned in sat/vB.
+func (r FeeRate) String() string {
+	return r.Format(PerVByte)
+}
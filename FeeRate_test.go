@@ -0,0 +1,115 @@
+package bitcoin
+
+import (
+	"testing"
+)
+
+func TestFeeRateConstructors(t *testing.T) {
+	cases := []struct {
+		rate     FeeRate
+		expected FeeRate
+	}{
+		{SatPerVByte(0), 0},
+		{SatPerVByte(1), 1000},
+		{SatPerVByte(8.3), 8300},
+		{SatPerVByte(0.1), 100},
+		{SatPerVByte(1.0005), 1001},
+		{SatPerKWU(253), 1012},
+		{SatPerKWU(250), 1000},
+		{SatPerKWU(2075), 8300},
+		{SatPerKWU(0.25), 1},
+	}
+
+	for i, c := range cases {
+		if c.rate != c.expected {
+			t.Errorf("case %d: %d sat/kvB, %d expected", i, c.rate, c.expected)
+		}
+	}
+}
+
+func TestFeeRateConversions(t *testing.T) {
+	r := SatPerVByte(8.3)
+
+	if r.SatPerVByte() != 8.3 {
+		t.Errorf("SatPerVByte() -> %f, 8.3 expected", r.SatPerVByte())
+	}
+
+	if r.SatPerKWU() != 2075 {
+		t.Errorf("SatPerKWU() -> %f, 2075 expected", r.SatPerKWU())
+	}
+}
+
+func TestFeeForVSize(t *testing.T) {
+	cases := []struct {
+		rate     FeeRate
+		vsize    int64
+		expected Amount
+	}{
+		{SatPerVByte(1), 0, 0},
+		{SatPerVByte(1), 141, 141},
+		{SatPerVByte(8.3), 150, 1245},
+		{SatPerVByte(8.3), 151, 1254},
+		{SatPerVByte(0.1), 1, 1},
+		{SatPerVByte(0.1), 110, 11},
+		{SatPerVByte(0.1), 111, 12},
+		{SatPerVByte(100), 1000000, BTC},
+	}
+
+	for _, c := range cases {
+		result := c.rate.FeeForVSize(c.vsize)
+
+		if result != c.expected {
+			t.Errorf("%s.FeeForVSize(%d) -> %d, %d expected", c.rate, c.vsize, result, c.expected)
+		}
+	}
+}
+
+func TestFeeForWeight(t *testing.T) {
+	cases := []struct {
+		rate     FeeRate
+		weight   int64
+		expected Amount
+	}{
+		{SatPerVByte(1), 0, 0},
+		{SatPerVByte(1), 561, 141},
+		{SatPerVByte(1), 564, 141},
+		{SatPerVByte(1), 565, 142},
+		{SatPerKWU(253), 1000, 253},
+	}
+
+	for _, c := range cases {
+		result := c.rate.FeeForWeight(c.weight)
+
+		if result != c.expected {
+			t.Errorf("%s.FeeForWeight(%d) -> %d, %d expected", c.rate, c.weight, result, c.expected)
+		}
+	}
+}
+
+func TestFeeRateFormat(t *testing.T) {
+	cases := []struct {
+		rate     FeeRate
+		unit     FeeRateUnit
+		expected string
+	}{
+		{0, PerVByte, "0 sat/vB"},
+		{SatPerVByte(1), PerVByte, "1 sat/vB"},
+		{SatPerVByte(8.3), PerVByte, "8.3 sat/vB"},
+		{SatPerVByte(0.123), PerVByte, "0.123 sat/vB"},
+		{SatPerVByte(8.3), PerKWU, "2075 sat/kWU"},
+		{SatPerKWU(253), PerKWU, "253 sat/kWU"},
+		{1, PerKWU, "0.25 sat/kWU"},
+	}
+
+	for _, c := range cases {
+		result := c.rate.Format(c.unit)
+
+		if result != c.expected {
+			t.Errorf("%d.Format(%d) -> '%s', '%s' expected", c.rate, c.unit, result, c.expected)
+		}
+	}
+
+	if SatPerVByte(8.3).String() != "8.3 sat/vB" {
+		t.Errorf("String() -> '%s', '8.3 sat/vB' expected", SatPerVByte(8.3).String())
+	}
+}
@@ -0,0 +1,99 @@
+package bitcoin
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+const (
+	// DefaultBondInterestRate is the yearly interest rate JoinMarket
+	// uses when valuing fidelity bonds.
+	DefaultBondInterestRate = 0.015
+
+	// DefaultBondExponent is the exponent JoinMarket applies to bond
+	// values to favour larger bonds.
+	DefaultBondExponent = 1.3
+)
+
+// year is the length of a year as used by JoinMarket, 365.2425 days.
+const year = 31556952 * time.Second
+
+// FidelityBond is a JoinMarket style timelocked fidelity bond.
+type FidelityBond struct {
+	// Value is the amount locked in the bond.
+	Value Amount
+
+	// Confirmed is the time the bond transaction was confirmed.
+	Confirmed time.Time
+
+	// Locktime is the time the bond can be spent.
+	Locktime time.Time
+}
+
+// BondValue returns the value of the bond at now, using the given
+// yearly interestRate and exponent. The value grows with both the
+// locked amount and the lock duration, and decays once the
+// locktime has passed. The result is unitless and only meaningful
+// when compared with other bonds valued with the same parameters.
+func (b FidelityBond) BondValue(now time.Time, interestRate float64, exponent float64) float64 {
+	locked := float64(b.Locktime.Sub(b.Confirmed)) / float64(year)
+	expired := math.Max(0, float64(now.Sub(b.Locktime))/float64(year))
+
+	a := math.Min(1, math.Exp(interestRate*locked)-1) - math.Min(1, math.Exp(interestRate*expired)-1)
+	a = math.Max(0, a)
+
+	return math.Pow(b.Value.Float64(Satoshi)*a, exponent)
+}
+
+// FidelityBondScript returns the witness script locking a fidelity
+// bond to pubkey until locktime:
+//
+//	<locktime> OP_CHECKLOCKTIMEVERIFY OP_DROP <pubkey> OP_CHECKSIG
+//
+// pubkey must be a 33 byte compressed public key.
+func FidelityBondScript(pubkey []byte, locktime time.Time) ([]byte, error) {
+	if len(pubkey) != 33 {
+		return nil, errors.New("pubkey must be 33 bytes")
+	}
+
+	if locktime.Unix() < 500000000 || locktime.Unix() > math.MaxUint32 {
+		return nil, errors.New("locktime out of range")
+	}
+
+	const (
+		opCheckLockTimeVerify = 0xb1
+		opDrop                = 0x75
+		opCheckSig            = 0xac
+	)
+
+	num := scriptNum(locktime.Unix())
+
+	script := make([]byte, 0, 1+len(num)+2+1+len(pubkey)+1)
+	script = append(script, byte(len(num)))
+	script = append(script, num...)
+	script = append(script, opCheckLockTimeVerify, opDrop)
+	script = append(script, byte(len(pubkey)))
+	script = append(script, pubkey...)
+	script = append(script, opCheckSig)
+
+	return script, nil
+}
+
+// scriptNum encodes a positive number as a minimal little-endian
+// script number.
+func scriptNum(n int64) []byte {
+	var out []byte
+
+	for n > 0 {
+		out = append(out, byte(n&0xff))
+		n >>= 8
+	}
+
+	// Keep the number positive if the sign bit is set.
+	if len(out) > 0 && out[len(out)-1]&0x80 != 0 {
+		out = append(out, 0)
+	}
+
+	return out
+}
@@ -0,0 +1,95 @@
+package bitcoin
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBondValue(t *testing.T) {
+	confirmed := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	locktime := confirmed.Add(year)
+
+	bond := FidelityBond{
+		Value:     BTC,
+		Confirmed: confirmed,
+		Locktime:  locktime,
+	}
+
+	cases := []struct {
+		now      time.Time
+		expected float64
+	}{
+		{confirmed, math.Pow(1e8*(math.Exp(0.015)-1), 1.3)},
+		{locktime, math.Pow(1e8*(math.Exp(0.015)-1), 1.3)},
+		{locktime.Add(year / 2), math.Pow(1e8*(math.Exp(0.015)-math.Exp(0.0075)), 1.3)},
+		{locktime.Add(2 * year), 0},
+	}
+
+	for _, c := range cases {
+		result := bond.BondValue(c.now, DefaultBondInterestRate, DefaultBondExponent)
+
+		if math.Abs(result-c.expected) > 1e-6*c.expected {
+			t.Errorf("BondValue(%s) -> %f, %f expected", c.now, result, c.expected)
+		}
+	}
+
+	double := bond
+	double.Value = 2 * BTC
+
+	if double.BondValue(confirmed, DefaultBondInterestRate, DefaultBondExponent) <= 2*bond.BondValue(confirmed, DefaultBondInterestRate, DefaultBondExponent) {
+		t.Errorf("BondValue() should grow faster than linearly with the value")
+	}
+}
+
+func TestFidelityBondScript(t *testing.T) {
+	pubkey, _ := hex.DecodeString("02" + "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	locktime := time.Unix(1640995200, 0)
+
+	script, err := FidelityBondScript(pubkey, locktime)
+	if err != nil {
+		t.Fatalf("FidelityBondScript() failed: %s", err.Error())
+	}
+
+	expected, _ := hex.DecodeString("048099cf61b1752102" + "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798" + "ac")
+
+	if !bytes.Equal(script, expected) {
+		t.Errorf("FidelityBondScript() -> %x, %x expected", script, expected)
+	}
+
+	_, err = FidelityBondScript(pubkey[1:], locktime)
+	if err == nil {
+		t.Errorf("FidelityBondScript() accepted a short pubkey")
+	}
+
+	_, err = FidelityBondScript(pubkey, time.Unix(1000, 0))
+	if err == nil {
+		t.Errorf("FidelityBondScript() accepted a block height locktime")
+	}
+}
+
+func TestScriptNum(t *testing.T) {
+	cases := []struct {
+		in       int64
+		expected []byte
+	}{
+		{0, nil},
+		{1, []byte{0x01}},
+		{127, []byte{0x7f}},
+		{128, []byte{0x80, 0x00}},
+		{255, []byte{0xff, 0x00}},
+		{256, []byte{0x00, 0x01}},
+		{1640995200, []byte{0x80, 0x99, 0xcf, 0x61}},
+		{math.MaxUint32, []byte{0xff, 0xff, 0xff, 0xff, 0x00}},
+	}
+
+	for _, c := range cases {
+		result := scriptNum(c.in)
+
+		if !bytes.Equal(result, c.expected) {
+			t.Errorf("scriptNum(%d) -> %x, %x expected", c.in, result, c.expected)
+		}
+	}
+}
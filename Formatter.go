@@ -0,0 +1,166 @@
+package bitcoin
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Formatter formats amounts for display with configurable
+// separators and precision. Danish users would typically see
+// "1.234.567,89 mBTC" from
+//
+//	Formatter{Unit: MilliBTC, Grouping: ".", Decimal: ",", MaxDecimals: 2, Suffix: " mBTC"}
+//
+// while English users would see "1,234,567.89 mBTC" with Grouping
+// and Decimal swapped.
+type Formatter struct {
+	// Unit is the unit amounts are shown in. It must be a power of
+	// ten satoshis. Zero means BTC.
+	Unit Amount
+
+	// Grouping separates groups of three digits in the whole part.
+	// Empty means no grouping.
+	Grouping string
+
+	// Decimal is the decimal mark. Empty means ".".
+	Decimal string
+
+	// MinDecimals is the minimum number of decimals shown. Missing
+	// decimals are padded with zeros.
+	MinDecimals int
+
+	// MaxDecimals is the maximum number of decimals shown. Amounts
+	// are rounded half away from zero to fit. Zero or a negative
+	// value shows every significant decimal.
+	MaxDecimals int
+
+	// NoDecimals rounds amounts half away from zero to whole units,
+	// overriding MaxDecimals.
+	NoDecimals bool
+
+	// Suffix is appended to the formatted number, for example
+	// " BTC".
+	Suffix string
+
+	// Parentheses shows negative amounts in parentheses instead of
+	// with a minus sign, like "(0.25 BTC)", as is common in
+	// accounting.
+	Parentheses bool
+
+	// PlusSign shows a "+" in front of positive amounts.
+	PlusSign bool
+}
+
+// Format returns a formatted according to f.
+func (f Formatter) Format(a Amount) string {
+	unit := f.Unit
+	if unit == 0 {
+		unit = BTC
+	}
+
+	decimal := f.Decimal
+	if decimal == "" {
+		decimal = "."
+	}
+
+	// Work on the magnitude so math.MinInt64 can be formatted.
+	negative := a < 0
+	magnitude := uint64(a)
+	if negative {
+		magnitude = uint64(-a)
+	}
+
+	u := uint64(unit)
+	whole := magnitude / u
+	frac := magnitude % u
+
+	precision := len(strconv.FormatUint(u, 10)) - 1
+
+	maxDecimals := f.MaxDecimals
+	if f.NoDecimals {
+		maxDecimals = 0
+	} else if maxDecimals <= 0 || maxDecimals > precision {
+		maxDecimals = precision
+	}
+
+	if maxDecimals < precision {
+		step := u
+		for i := 0; i < maxDecimals; i++ {
+			step /= 10
+		}
+
+		rest := frac % step
+		frac -= rest
+
+		if rest*2 >= step {
+			frac += step
+
+			if frac == u {
+				frac = 0
+				whole++
+			}
+		}
+	}
+
+	fracStr := ""
+	if precision > 0 {
+		fracStr = strconv.FormatUint(frac, 10)
+		fracStr = strings.Repeat("0", precision-len(fracStr)) + fracStr
+		fracStr = fracStr[:maxDecimals]
+		fracStr = strings.TrimRight(fracStr, "0")
+	}
+
+	if len(fracStr) < f.MinDecimals {
+		fracStr += strings.Repeat("0", f.MinDecimals-len(fracStr))
+	}
+
+	out := make([]byte, 0, 32)
+
+	// Amounts rounded to zero are shown without a sign.
+	nonZero := whole != 0 || frac != 0
+	parentheses := negative && nonZero && f.Parentheses
+
+	switch {
+	case parentheses:
+		out = append(out, '(')
+
+	case negative && nonZero:
+		out = append(out, '-')
+
+	case !negative && nonZero && f.PlusSign:
+		out = append(out, '+')
+	}
+
+	out = appendGrouped(out, strconv.FormatUint(whole, 10), f.Grouping)
+
+	if fracStr != "" {
+		out = append(out, decimal...)
+		out = append(out, fracStr...)
+	}
+
+	out = append(out, f.Suffix...)
+
+	if parentheses {
+		out = append(out, ')')
+	}
+
+	return string(out)
+}
+
+// appendGrouped appends digits to dst with separator between every
+// group of three digits, counted from the right.
+func appendGrouped(dst []byte, digits string, separator string) []byte {
+	if separator == "" {
+		return append(dst, digits...)
+	}
+
+	for i := 0; i < len(digits); i++ {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			dst = append(dst, separator...)
+		}
+
+		dst = append(dst, digits[i])
+	}
+
+	return dst
+}
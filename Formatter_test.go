@@ -0,0 +1,87 @@
+package bitcoin
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFormatter(t *testing.T) {
+	danish := Formatter{Unit: MilliBTC, Grouping: ".", Decimal: ",", MaxDecimals: 2, Suffix: " mBTC"}
+	english := Formatter{Unit: BTC, Grouping: ",", Decimal: ".", Suffix: " BTC"}
+	accounting := Formatter{MaxDecimals: 2, Suffix: " BTC", Parentheses: true}
+
+	cases := []struct {
+		f        Formatter
+		in       Amount
+		expected string
+	}{
+		{danish, 1234567*MilliBTC + 890*MicroBTC, "1.234.567,89 mBTC"},
+		{danish, 1234567*MilliBTC + 894*MicroBTC, "1.234.567,89 mBTC"},
+		{danish, 1234567*MilliBTC + 895*MicroBTC, "1.234.567,9 mBTC"},
+		{danish, 999*MilliBTC + 999*MicroBTC, "1.000 mBTC"},
+		{danish, -1234567 * MilliBTC, "-1.234.567 mBTC"},
+		{danish, -4 * MicroBTC, "0 mBTC"},
+		{danish, 0, "0 mBTC"},
+		{english, 1234567*BTC + 890*MilliBTC, "1,234,567.89 BTC"},
+		{english, 123 * BTC, "123 BTC"},
+		{english, 1234 * BTC, "1,234 BTC"},
+		{english, Satoshi, "0.00000001 BTC"},
+		{english, -Satoshi, "-0.00000001 BTC"},
+		{english, math.MaxInt64, "92,233,720,368.54775807 BTC"},
+		{english, math.MinInt64, "-92,233,720,368.54775808 BTC"},
+		{Formatter{}, 1500 * MilliBTC, "1.5"},
+		{Formatter{}, Satoshi, "0.00000001"},
+		{Formatter{MaxDecimals: -1}, 1500 * MilliBTC, "1.5"},
+		{Formatter{MinDecimals: 2}, 1500 * MilliBTC, "1.50"},
+		{Formatter{MaxDecimals: 1, MinDecimals: 3}, 1500 * MilliBTC, "1.500"},
+		{Formatter{NoDecimals: true}, 1500 * MilliBTC, "2"},
+		{Formatter{NoDecimals: true}, 1400 * MilliBTC, "1"},
+		{Formatter{NoDecimals: true}, -1500 * MilliBTC, "-2"},
+		{Formatter{NoDecimals: true, MaxDecimals: 3}, 1500 * MilliBTC, "2"},
+		{Formatter{NoDecimals: true, MinDecimals: 2}, 1500 * MilliBTC, "2.00"},
+		{Formatter{Unit: Satoshi, Grouping: " "}, 1234567, "1 234 567"},
+		{Formatter{Unit: Satoshi, MinDecimals: 2}, 12, "12.00"},
+		{Formatter{Unit: 100 * BTC}, 1234 * BTC, "12.34"},
+		{accounting, -250 * MilliBTC, "(0.25 BTC)"},
+		{accounting, 250 * MilliBTC, "0.25 BTC"},
+		{accounting, math.MinInt64, "(92233720368.55 BTC)"},
+		{accounting, -Satoshi, "0 BTC"},
+		{Formatter{PlusSign: true}, 250 * MilliBTC, "+0.25"},
+		{Formatter{PlusSign: true}, -250 * MilliBTC, "-0.25"},
+		{Formatter{PlusSign: true}, 0, "0"},
+		{Formatter{NoDecimals: true, PlusSign: true}, Satoshi, "0"},
+		{Formatter{PlusSign: true, Parentheses: true}, -BTC, "(1)"},
+	}
+
+	for _, c := range cases {
+		result := c.f.Format(c.in)
+
+		if result != c.expected {
+			t.Errorf("%+v.Format(%d) -> '%s', '%s' expected", c.f, c.in, result, c.expected)
+		}
+	}
+}
+
+func TestAppendGrouped(t *testing.T) {
+	cases := []struct {
+		in        string
+		separator string
+		expected  string
+	}{
+		{"0", ",", "0"},
+		{"123", ",", "123"},
+		{"1234", ",", "1,234"},
+		{"123456", ",", "123,456"},
+		{"1234567", "'", "1'234'567"},
+		{"1234567", "", "1234567"},
+		{"1234567", " ", "1 234 567"},
+	}
+
+	for _, c := range cases {
+		result := string(appendGrouped(nil, c.in, c.separator))
+
+		if result != c.expected {
+			t.Errorf("appendGrouped('%s', '%s') -> '%s', '%s' expected", c.in, c.separator, result, c.expected)
+		}
+	}
+}
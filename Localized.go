@@ -0,0 +1,172 @@
+package bitcoin
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// numberConvention describes how a locale writes numbers.
+type numberConvention struct {
+	decimal  string
+	grouping string
+
+	// indian allows groups of two digits, like 1,00,000.
+	indian bool
+}
+
+var (
+	pointComma  = numberConvention{decimal: ".", grouping: ","}
+	commaPoint  = numberConvention{decimal: ",", grouping: "."}
+	commaSpace  = numberConvention{decimal: ",", grouping: " \u00a0\u202f"}
+	pointQuote  = numberConvention{decimal: ".", grouping: "'’"}
+	arabic      = numberConvention{decimal: "٫.", grouping: "٬,"}
+	indianComma = numberConvention{decimal: ".", grouping: ",", indian: true}
+)
+
+// separators holds every decimal and grouping separator of the
+// known conventions.
+const separators = ".,'’_ \u00a0\u202f٫٬"
+
+// numberConventions maps BCP 47 language and language-region tags to
+// the way they write numbers.
+var numberConventions = map[string]numberConvention{
+	"en": pointComma, "ja": pointComma, "zh": pointComma, "ko": pointComma,
+	"th": pointComma, "he": pointComma, "ms": pointComma, "fil": pointComma,
+
+	"da": commaPoint, "de": commaPoint, "nl": commaPoint, "it": commaPoint,
+	"es": commaPoint, "pt": commaPoint, "id": commaPoint, "tr": commaPoint,
+	"el": commaPoint, "ro": commaPoint, "hr": commaPoint, "sl": commaPoint,
+	"sr": commaPoint, "vi": commaPoint,
+
+	"fr": commaSpace, "nb": commaSpace, "no": commaSpace, "nn": commaSpace,
+	"sv": commaSpace, "fi": commaSpace, "cs": commaSpace, "sk": commaSpace,
+	"pl": commaSpace, "ru": commaSpace, "uk": commaSpace, "hu": commaSpace,
+	"bg": commaSpace, "et": commaSpace, "lv": commaSpace, "lt": commaSpace,
+	"pt-PT": commaSpace,
+
+	"de-CH": pointQuote, "fr-CH": pointQuote, "it-CH": pointQuote, "de-LI": pointQuote,
+
+	"ar": arabic, "fa": arabic,
+
+	"hi": indianComma, "bn": indianComma, "mr": indianComma, "ta": indianComma,
+	"te": indianComma, "en-IN": indianComma,
+}
+
+// ParseLocalized parses an amount in BTC written the way the locale
+// identified by the BCP 47 tag writes numbers, for example "0,5" or
+// "1.234,5" for "da-DK", "1 234,5" for "fr" and "1'234.5" for
+// "de-CH". A language.Tag from golang.org/x/text can be passed using
+// its String method. Non-ASCII digits are accepted.
+// Grouping separators must separate groups of three digits, with at
+// most three digits before the first, so a Danish "0.5" is rejected
+// rather than read as 5 BTC. Separators the
+// locale does not use are rejected too, like "1.500" for "fr".
+func ParseLocalized(input string, tag string) (Amount, error) {
+	conv, ok := lookupConvention(tag)
+	if !ok {
+		return 0, errors.New("unsupported locale '" + tag + "'")
+	}
+
+	var out strings.Builder
+
+	errGrouping := errors.New("parse error, misplaced grouping separator in '" + input + "'")
+
+	decimals := false
+
+	// lead counts the digits before the first grouping separator,
+	// which may be at most three, or two for Indian grouping.
+	lead := 0
+	maxLead := 3
+	if conv.indian {
+		maxLead = 2
+	}
+
+	// group counts the digits since the last grouping separator, or
+	// is -1 if there was none.
+	group := -1
+
+	// validGroup reports whether the digits since the last grouping
+	// separator form a valid group. Indian grouping allows groups of
+	// two digits, except for the last.
+	validGroup := func(last bool) bool {
+		return group == -1 || group == 3 || (conv.indian && group == 2 && !last)
+	}
+
+	for _, r := range strings.TrimSpace(input) {
+		switch {
+		case r >= '0' && r <= '9', unicode.IsDigit(r):
+			out.WriteRune('0' + digitValue(r))
+
+			if group >= 0 {
+				group++
+			} else if !decimals {
+				lead++
+			}
+
+		case strings.ContainsRune(conv.decimal, r):
+			if !validGroup(true) {
+				return 0, errGrouping
+			}
+
+			decimals = true
+			group = -1
+
+			out.WriteRune('.')
+
+		case strings.ContainsRune(conv.grouping, r):
+			if decimals || lead == 0 || !validGroup(false) {
+				return 0, errGrouping
+			}
+
+			if group == -1 && lead > maxLead {
+				return 0, errGrouping
+			}
+
+			group = 0
+
+		case r == '−':
+			out.WriteRune('-')
+
+		case strings.ContainsRune(separators, r):
+			// A separator the locale does not use would otherwise be
+			// read as a decimal point by Parse.
+			return 0, errGrouping
+
+		default:
+			out.WriteRune(r)
+		}
+	}
+
+	if !validGroup(true) {
+		return 0, errGrouping
+	}
+
+	return Parse(out.String())
+}
+
+// lookupConvention finds the number convention for a BCP 47 tag,
+// trying language-region before language alone.
+func lookupConvention(tag string) (numberConvention, bool) {
+	parts := strings.FieldsFunc(tag, func(r rune) bool {
+		return r == '-' || r == '_'
+	})
+
+	if len(parts) == 0 {
+		return numberConvention{}, false
+	}
+
+	lang := strings.ToLower(parts[0])
+
+	for _, part := range parts[1:] {
+		if len(part) == 2 {
+			if conv, ok := numberConventions[lang+"-"+strings.ToUpper(part)]; ok {
+				return conv, true
+			}
+		}
+	}
+
+	conv, ok := numberConventions[lang]
+
+	return conv, ok
+}
@@ -0,0 +1,60 @@
+package bitcoin
+
+import (
+	"testing"
+)
+
+func TestParseLocalized(t *testing.T) {
+	cases := []struct {
+		in       string
+		tag      string
+		expected Amount
+		err      bool
+	}{
+		{"0.5", "en", 50000000, false},
+		{"1,234.5", "en-US", 123450000000, false},
+		{"1,5", "en", 0, true},
+		{"0,5", "da-DK", 50000000, false},
+		{"1.234,5", "da", 123450000000, false},
+		{"0.5", "da", 0, true},
+		{"1.234.567,00000001", "de", 123456700000001, false},
+		{"1.23,5", "de", 0, true},
+		{"1,234.5", "de", 0, true},
+		{"1 234,5", "fr", 123450000000, false},
+		{"1 234,5", "fr-FR", 123450000000, false},
+		{"1 234,5", "fr", 123450000000, false},
+		{"-1 234,5", "sv_SE", -123450000000, false},
+		{"1'234.5", "de-CH", 123450000000, false},
+		{"1’234.5", "de-CH", 123450000000, false},
+		{"1.234,5", "pt-BR", 123450000000, false},
+		{"1 234,5", "pt-PT", 123450000000, false},
+		{"١٬٢٣٤٫٥", "ar-EG", 123450000000, false},
+		{"1,00,000.5", "hi-IN", 10000050000000, false},
+		{"1,00,000.5", "en-IN", 10000050000000, false},
+		{"1,00,00", "hi", 0, true},
+		{",123", "en", 0, true},
+		{"-,123", "en", 0, true},
+		{"-1,234", "en", -123400000000, false},
+		{"12345,678", "en", 0, true},
+		{"123,456,789", "en", 12345678900000000, false},
+		{"1234.567,5", "da", 0, true},
+		{"100,000", "hi", 0, true},
+		{"12,34,567", "hi", 123456700000000, false},
+		{"1.500", "fr", 0, true},
+		{"1,234", "de-CH", 0, true},
+		{"1,5", "de-CH", 0, true},
+		{"1'234,5", "de", 0, true},
+		{"1 234.5", "en", 0, true},
+		{"1_234,5", "da", 0, true},
+		{"1.5", "xx", 0, true},
+		{"1.5", "", 0, true},
+	}
+
+	for _, c := range cases {
+		result, err := ParseLocalized(c.in, c.tag)
+
+		if result != c.expected || (err != nil) != c.err {
+			t.Errorf("ParseLocalized('%s', '%s') -> %d, %v, %d expected", c.in, c.tag, result, err, c.expected)
+		}
+	}
+}
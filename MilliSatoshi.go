@@ -0,0 +1,67 @@
+package bitcoin
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// MilliSatoshi is an integer amount in thousandths of a satoshi, as
+// used by Lightning invoices and channel balances.
+type MilliSatoshi int64
+
+// MilliSatoshis returns a in millisatoshis, or ErrOverflow if it
+// does not fit.
+func (a Amount) MilliSatoshis() (MilliSatoshi, error) {
+	m, err := a.MulChecked(1000)
+	if err != nil {
+		return 0, err
+	}
+
+	return MilliSatoshi(m), nil
+}
+
+// Amount returns m in satoshis, rounded using mode. Use RoundFloor
+// when paying out and RoundCeil when charging, so rounding never
+// favours the other party.
+func (m MilliSatoshi) Amount(mode RoundingMode) Amount {
+	return divRound(Amount(m), 1000, mode)
+}
+
+// AmountExact returns m in satoshis, or ErrPrecision if m is not a
+// whole number of satoshis.
+func (m MilliSatoshi) AmountExact() (Amount, error) {
+	if m%1000 != 0 {
+		return 0, ErrPrecision
+	}
+
+	return Amount(m / 1000), nil
+}
+
+// String implements fmt.Stringer. 1500 millisatoshis is returned as
+// "1500 msat".
+func (m MilliSatoshi) String() string {
+	return strconv.FormatInt(int64(m), 10) + " msat"
+}
+
+// ParseMilliSatoshi parses an integer number of millisatoshis, with
+// an optional "msat" or "msats" suffix. It accepts the output of
+// String, and values like "1500msat" as used by Lightning
+// implementations.
+func ParseMilliSatoshi(in string) (MilliSatoshi, error) {
+	in = strings.TrimSpace(in)
+	in = strings.TrimSuffix(in, "msats")
+	in = strings.TrimSuffix(in, "msat")
+	in = strings.TrimSpace(in)
+
+	v, err := strconv.ParseInt(in, 10, 64)
+	if err != nil {
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+			return 0, ErrOverflow
+		}
+
+		return 0, errors.New("parse error, invalid millisatoshi amount '" + in + "'")
+	}
+
+	return MilliSatoshi(v), nil
+}
@@ -0,0 +1,121 @@
+package bitcoin
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMilliSatoshis(t *testing.T) {
+	cases := []struct {
+		in       Amount
+		expected MilliSatoshi
+		err      error
+	}{
+		{0, 0, nil},
+		{Satoshi, 1000, nil},
+		{-Satoshi, -1000, nil},
+		{BTC, 100000000000, nil},
+		{AllBTC, 2099999997690000000, nil},
+		{math.MaxInt64 / 1000, math.MaxInt64 / 1000 * 1000, nil},
+		{math.MaxInt64/1000 + 1, 0, ErrOverflow},
+	}
+
+	for _, c := range cases {
+		result, err := c.in.MilliSatoshis()
+
+		if result != c.expected || err != c.err {
+			t.Errorf("%d.MilliSatoshis() -> %d, %v, %d, %v expected", c.in, result, err, c.expected, c.err)
+		}
+	}
+}
+
+func TestMilliSatoshiAmount(t *testing.T) {
+	cases := []struct {
+		in       MilliSatoshi
+		mode     RoundingMode
+		expected Amount
+	}{
+		{0, RoundFloor, 0},
+		{1000, RoundFloor, Satoshi},
+		{1999, RoundFloor, Satoshi},
+		{1001, RoundCeil, 2 * Satoshi},
+		{1500, RoundHalfUp, 2 * Satoshi},
+		{1499, RoundHalfUp, Satoshi},
+		{2500, RoundHalfEven, 2 * Satoshi},
+		{-1500, RoundFloor, -2 * Satoshi},
+		{-1500, RoundCeil, -Satoshi},
+		{-1500, RoundTowardZero, -Satoshi},
+	}
+
+	for _, c := range cases {
+		result := c.in.Amount(c.mode)
+
+		if result != c.expected {
+			t.Errorf("%d.Amount(%d) -> %d, %d expected", c.in, c.mode, result, c.expected)
+		}
+	}
+}
+
+func TestMilliSatoshiAmountExact(t *testing.T) {
+	cases := []struct {
+		in       MilliSatoshi
+		expected Amount
+		err      error
+	}{
+		{0, 0, nil},
+		{1000, Satoshi, nil},
+		{-21000, -21 * Satoshi, nil},
+		{1001, 0, ErrPrecision},
+		{-1, 0, ErrPrecision},
+	}
+
+	for _, c := range cases {
+		result, err := c.in.AmountExact()
+
+		if result != c.expected || err != c.err {
+			t.Errorf("%d.AmountExact() -> %d, %v, %d, %v expected", c.in, result, err, c.expected, c.err)
+		}
+	}
+}
+
+func TestParseMilliSatoshi(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected MilliSatoshi
+		err      bool
+	}{
+		{"0", 0, false},
+		{"1500", 1500, false},
+		{"1500msat", 1500, false},
+		{"1500 msat", 1500, false},
+		{"1500 msats", 1500, false},
+		{"-1500 msat", -1500, false},
+		{" 21 ", 21, false},
+		{"9223372036854775807", math.MaxInt64, false},
+		{"9223372036854775808", 0, true},
+		{"", 0, true},
+		{"1.5", 0, true},
+		{"1500 sats", 0, true},
+	}
+
+	for _, c := range cases {
+		result, err := ParseMilliSatoshi(c.in)
+
+		if result != c.expected || (err != nil) != c.err {
+			t.Errorf("ParseMilliSatoshi('%s') -> %d, %v, %d expected", c.in, result, err, c.expected)
+		}
+	}
+
+	overflow, err := ParseMilliSatoshi("9223372036854775808")
+	if overflow != 0 || err != ErrOverflow {
+		t.Errorf("ParseMilliSatoshi() -> %d, %v, 0, %v expected", overflow, err, ErrOverflow)
+	}
+
+	for _, m := range []MilliSatoshi{0, 1, -1500, math.MaxInt64} {
+		result, err := ParseMilliSatoshi(m.String())
+
+		if result != m || err != nil {
+			t.Errorf("ParseMilliSatoshi('%s') -> %d, %v, %d expected", m.String(), result, err, m)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package bitcoin
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Fiat is a fixed-point amount of a fiat currency. A Value of 1234
+// with 2 Decimals is 12.34.
+type Fiat struct {
+	// Currency is the ISO 4217 currency code, like "EUR".
+	Currency string
+
+	// Value is the amount in units of 10^-Decimals.
+	Value int64
+
+	// Decimals is the number of decimals in Value, typically 2 for
+	// currencies with cents.
+	Decimals int
+}
+
+// Price is the price of one bitcoin in a fiat currency, in the same
+// fixed-point form as Fiat. A Price of 2512345 EUR with 2 Decimals
+// means 1 BTC costs 25123.45 EUR.
+type Price struct {
+	// Currency is the ISO 4217 currency code, like "EUR".
+	Currency string
+
+	// Value is the price of one BTC in units of 10^-Decimals.
+	Value int64
+
+	// Decimals is the number of decimals in Value.
+	Decimals int
+}
+
+// TwoWayRate is a quote with separate prices for buying and selling
+// bitcoin, seen from the customer's side.
+type TwoWayRate struct {
+	// Bid is the price the customer receives when selling bitcoin.
+	Bid Price
+
+	// Ask is the price the customer pays when buying bitcoin.
+	Ask Price
+}
+
+// String implements fmt.Stringer. Fiat{"EUR", -1234, 2} is returned
+// as "-12.34 EUR".
+func (f Fiat) String() string {
+	return formatFixed(f.Value, f.Decimals) + " " + f.Currency
+}
+
+// String implements fmt.Stringer. The price is returned like
+// "25123.45 EUR/BTC".
+func (p Price) String() string {
+	return formatFixed(p.Value, p.Decimals) + " " + p.Currency + "/BTC"
+}
+
+// FiatValue returns the value of a at price p, rounded to p.Decimals
+// using mode. The price must be positive. ErrOverflow is returned if
+// the value does not fit in an int64.
+func (a Amount) FiatValue(p Price, mode RoundingMode) (Fiat, error) {
+	if p.Value <= 0 {
+		return Fiat{}, errors.New("price must be positive")
+	}
+
+	if p.Decimals < 0 {
+		return Fiat{}, errors.New("negative decimals in price")
+	}
+
+	// a * p.Value / BTC
+	num := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(p.Value))
+	value := bigDivRound(num, big.NewInt(int64(BTC)), mode)
+
+	if !value.IsInt64() {
+		return Fiat{}, ErrOverflow
+	}
+
+	return Fiat{Currency: p.Currency, Value: value.Int64(), Decimals: p.Decimals}, nil
+}
+
+// AmountFromFiat returns the amount of bitcoin worth f at price p,
+// rounded to satoshis using mode. f and p must be in the same
+// currency, but may use different decimals.
+func AmountFromFiat(f Fiat, p Price, mode RoundingMode) (Amount, error) {
+	if f.Currency != p.Currency {
+		return 0, errors.New("currency mismatch: " + f.Currency + " and " + p.Currency)
+	}
+
+	if p.Value <= 0 {
+		return 0, errors.New("price must be positive")
+	}
+
+	if f.Decimals < 0 || p.Decimals < 0 {
+		return 0, errors.New("negative decimals")
+	}
+
+	// f.Value / 10^f.Decimals * BTC / (p.Value / 10^p.Decimals)
+	num := new(big.Int).Mul(big.NewInt(f.Value), big.NewInt(int64(BTC)))
+	num.Mul(num, pow10(p.Decimals))
+
+	den := new(big.Int).Mul(big.NewInt(p.Value), pow10(f.Decimals))
+
+	value := bigDivRound(num, den, mode)
+
+	if !value.IsInt64() {
+		return 0, ErrOverflow
+	}
+
+	return Amount(value.Int64()), nil
+}
+
+// Validate checks that both sides are positive prices in the same
+// currency and that the bid does not exceed the ask.
+func (r TwoWayRate) Validate() error {
+	if r.Bid.Currency != r.Ask.Currency {
+		return errors.New("currency mismatch: " + r.Bid.Currency + " and " + r.Ask.Currency)
+	}
+
+	if r.Bid.Value <= 0 || r.Ask.Value <= 0 {
+		return errors.New("prices must be positive")
+	}
+
+	if r.Bid.Decimals < 0 || r.Ask.Decimals < 0 {
+		return errors.New("negative decimals")
+	}
+
+	// Compare bid and ask, which may use different decimals.
+	bid := new(big.Int).Mul(big.NewInt(r.Bid.Value), pow10(r.Ask.Decimals))
+	ask := new(big.Int).Mul(big.NewInt(r.Ask.Value), pow10(r.Bid.Decimals))
+
+	if bid.Cmp(ask) > 0 {
+		return errors.New("bid above ask")
+	}
+
+	return nil
+}
+
+// BuyCost returns what the customer pays for buying a, at the ask.
+func (r TwoWayRate) BuyCost(a Amount, mode RoundingMode) (Fiat, error) {
+	err := r.Validate()
+	if err != nil {
+		return Fiat{}, err
+	}
+
+	return a.FiatValue(r.Ask, mode)
+}
+
+// SellProceeds returns what the customer receives for selling a, at
+// the bid.
+func (r TwoWayRate) SellProceeds(a Amount, mode RoundingMode) (Fiat, error) {
+	err := r.Validate()
+	if err != nil {
+		return Fiat{}, err
+	}
+
+	return a.FiatValue(r.Bid, mode)
+}
+
+// BuyAmount returns the amount the customer gets when buying
+// bitcoin for f, at the ask.
+func (r TwoWayRate) BuyAmount(f Fiat, mode RoundingMode) (Amount, error) {
+	err := r.Validate()
+	if err != nil {
+		return 0, err
+	}
+
+	return AmountFromFiat(f, r.Ask, mode)
+}
+
+// SellAmount returns the amount the customer must sell to receive f,
+// at the bid.
+func (r TwoWayRate) SellAmount(f Fiat, mode RoundingMode) (Amount, error) {
+	err := r.Validate()
+	if err != nil {
+		return 0, err
+	}
+
+	return AmountFromFiat(f, r.Bid, mode)
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// formatFixed formats value with the decimal point placed decimals
+// digits from the right.
+func formatFixed(value int64, decimals int) string {
+	digits := strconv.FormatInt(value, 10)
+
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		digits = digits[1:]
+	}
+
+	if decimals <= 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	point := len(digits) - decimals
+
+	return sign + digits[:point] + "." + digits[point:]
+}
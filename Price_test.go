@@ -0,0 +1,174 @@
+package bitcoin
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFiatValue(t *testing.T) {
+	eur := Price{"EUR", 2512345, 2}
+	jpy := Price{"JPY", 4000000, 0}
+
+	cases := []struct {
+		in       Amount
+		p        Price
+		mode     RoundingMode
+		expected Fiat
+		err      error
+	}{
+		{0, eur, RoundHalfUp, Fiat{"EUR", 0, 2}, nil},
+		{BTC, eur, RoundHalfUp, Fiat{"EUR", 2512345, 2}, nil},
+		{-BTC, eur, RoundHalfUp, Fiat{"EUR", -2512345, 2}, nil},
+		{MilliBTC, eur, RoundHalfUp, Fiat{"EUR", 2512, 2}, nil},
+		{MilliBTC, eur, RoundCeil, Fiat{"EUR", 2513, 2}, nil},
+		{MilliBTC, eur, RoundFloor, Fiat{"EUR", 2512, 2}, nil},
+		{2 * MilliBTC, eur, RoundHalfUp, Fiat{"EUR", 5025, 2}, nil},
+		{2 * MilliBTC, eur, RoundHalfEven, Fiat{"EUR", 5025, 2}, nil},
+		{200 * Satoshi, Price{"EUR", 2500000, 2}, RoundHalfEven, Fiat{"EUR", 5, 2}, nil},
+		{20 * Satoshi, Price{"EUR", 2500000, 2}, RoundHalfEven, Fiat{"EUR", 0, 2}, nil},
+		{20 * Satoshi, Price{"EUR", 2500000, 2}, RoundHalfUp, Fiat{"EUR", 1, 2}, nil},
+		{Satoshi, eur, RoundHalfUp, Fiat{"EUR", 0, 2}, nil},
+		{12345678, jpy, RoundHalfUp, Fiat{"JPY", 493827, 0}, nil},
+		{AllBTC, Price{"XXX", math.MaxInt64, 0}, RoundHalfUp, Fiat{}, ErrOverflow},
+	}
+
+	for _, c := range cases {
+		result, err := c.in.FiatValue(c.p, c.mode)
+
+		if result != c.expected || err != c.err {
+			t.Errorf("%d.FiatValue(%s, %d) -> %s, %v, %s, %v expected", c.in, c.p, c.mode, result, err, c.expected, c.err)
+		}
+	}
+}
+
+func TestFiatValueInvalidPrice(t *testing.T) {
+	prices := []Price{
+		{"EUR", 0, 2},
+		{"EUR", -2512345, 2},
+		{"EUR", 2512345, -1},
+	}
+
+	for _, p := range prices {
+		_, err := BTC.FiatValue(p, RoundHalfUp)
+		if err == nil {
+			t.Errorf("%d.FiatValue(%s) should fail", BTC, p)
+		}
+	}
+}
+
+func TestAmountFromFiat(t *testing.T) {
+	eur := Price{"EUR", 2512345, 2}
+
+	cases := []struct {
+		in       Fiat
+		p        Price
+		mode     RoundingMode
+		expected Amount
+		err      bool
+	}{
+		{Fiat{"EUR", 0, 2}, eur, RoundHalfUp, 0, false},
+		{Fiat{"EUR", 2512345, 2}, eur, RoundHalfUp, BTC, false},
+		{Fiat{"EUR", 25123450, 3}, eur, RoundHalfUp, BTC, false},
+		{Fiat{"EUR", 25123, 0}, eur, RoundHalfUp, 99998209, false},
+		{Fiat{"EUR", 1000, 2}, eur, RoundHalfUp, 39803, false},
+		{Fiat{"EUR", 1000, 2}, eur, RoundCeil, 39804, false},
+		{Fiat{"EUR", -1000, 2}, eur, RoundHalfUp, -39803, false},
+		{Fiat{"EUR", -1000, 2}, eur, RoundFloor, -39804, false},
+		{Fiat{"EUR", 1, 2}, Price{"EUR", 100, 2}, RoundHalfUp, 10 * MilliBTC, false},
+		{Fiat{"USD", 1000, 2}, eur, RoundHalfUp, 0, true},
+		{Fiat{"EUR", 1000, 2}, Price{"EUR", 0, 2}, RoundHalfUp, 0, true},
+		{Fiat{"EUR", 1000, -1}, eur, RoundHalfUp, 0, true},
+		{Fiat{"EUR", math.MaxInt64, 0}, Price{"EUR", 1, 0}, RoundHalfUp, 0, true},
+	}
+
+	for _, c := range cases {
+		result, err := AmountFromFiat(c.in, c.p, c.mode)
+
+		if result != c.expected || (err != nil) != c.err {
+			t.Errorf("AmountFromFiat(%s, %s, %d) -> %d, %v, %d expected", c.in, c.p, c.mode, result, err, c.expected)
+		}
+	}
+}
+
+func TestFiatString(t *testing.T) {
+	cases := []struct {
+		in       Fiat
+		expected string
+	}{
+		{Fiat{"EUR", 0, 2}, "0.00 EUR"},
+		{Fiat{"EUR", 1234, 2}, "12.34 EUR"},
+		{Fiat{"EUR", -1234, 2}, "-12.34 EUR"},
+		{Fiat{"EUR", 5, 2}, "0.05 EUR"},
+		{Fiat{"EUR", -5, 2}, "-0.05 EUR"},
+		{Fiat{"JPY", 4000, 0}, "4000 JPY"},
+		{Fiat{"BHD", 1234, 3}, "1.234 BHD"},
+	}
+
+	for _, c := range cases {
+		result := c.in.String()
+
+		if result != c.expected {
+			t.Errorf("String() -> '%s', '%s' expected", result, c.expected)
+		}
+	}
+
+	if s := (Price{"EUR", 2512345, 2}).String(); s != "25123.45 EUR/BTC" {
+		t.Errorf("Price.String() -> '%s', '25123.45 EUR/BTC' expected", s)
+	}
+}
+
+func TestTwoWayRate(t *testing.T) {
+	r := TwoWayRate{
+		Bid: Price{"EUR", 2500000, 2},
+		Ask: Price{"EUR", 2600000, 2},
+	}
+
+	cost, err := r.BuyCost(BTC, RoundCeil)
+	if cost != (Fiat{"EUR", 2600000, 2}) || err != nil {
+		t.Errorf("BuyCost() -> %s, %v", cost, err)
+	}
+
+	proceeds, err := r.SellProceeds(BTC, RoundFloor)
+	if proceeds != (Fiat{"EUR", 2500000, 2}) || err != nil {
+		t.Errorf("SellProceeds() -> %s, %v", proceeds, err)
+	}
+
+	bought, err := r.BuyAmount(Fiat{"EUR", 26000, 2}, RoundFloor)
+	if bought != 10*MilliBTC || err != nil {
+		t.Errorf("BuyAmount() -> %d, %v", bought, err)
+	}
+
+	sold, err := r.SellAmount(Fiat{"EUR", 25000, 2}, RoundCeil)
+	if sold != 10*MilliBTC || err != nil {
+		t.Errorf("SellAmount() -> %d, %v", sold, err)
+	}
+}
+
+func TestTwoWayRateValidate(t *testing.T) {
+	cases := []struct {
+		r     TwoWayRate
+		valid bool
+	}{
+		{TwoWayRate{Price{"EUR", 100, 2}, Price{"EUR", 101, 2}}, true},
+		{TwoWayRate{Price{"EUR", 100, 2}, Price{"EUR", 100, 2}}, true},
+		{TwoWayRate{Price{"EUR", 100, 2}, Price{"EUR", 1000, 3}}, true},
+		{TwoWayRate{Price{"EUR", 100, 2}, Price{"EUR", 999, 3}}, false},
+		{TwoWayRate{Price{"EUR", 101, 2}, Price{"EUR", 100, 2}}, false},
+		{TwoWayRate{Price{"EUR", 100, 2}, Price{"USD", 101, 2}}, false},
+		{TwoWayRate{Price{"EUR", 0, 2}, Price{"EUR", 101, 2}}, false},
+		{TwoWayRate{Price{"EUR", 100, -2}, Price{"EUR", 101, 2}}, false},
+	}
+
+	for _, c := range cases {
+		err := c.r.Validate()
+
+		if (err == nil) != c.valid {
+			t.Errorf("%s/%s.Validate() -> %v, valid=%v expected", c.r.Bid, c.r.Ask, err, c.valid)
+		}
+
+		_, err = c.r.BuyCost(BTC, RoundHalfUp)
+		if (err == nil) != c.valid {
+			t.Errorf("%s/%s.BuyCost() -> %v, valid=%v expected", c.r.Bid, c.r.Ask, err, c.valid)
+		}
+	}
+}
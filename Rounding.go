@@ -0,0 +1,129 @@
+package bitcoin
+
+import (
+	"math/big"
+)
+
+// RoundingMode selects how a value is rounded when it cannot be
+// represented exactly.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest value, with halves rounded
+	// away from zero. 2.5 becomes 3 and -2.5 becomes -3.
+	RoundHalfUp RoundingMode = iota
+
+	// RoundHalfEven rounds to the nearest value, with halves rounded
+	// to the nearest even value. Also known as banker's rounding.
+	// 2.5 becomes 2 and 3.5 becomes 4.
+	RoundHalfEven
+
+	// RoundFloor rounds towards negative infinity.
+	RoundFloor
+
+	// RoundCeil rounds towards positive infinity.
+	RoundCeil
+
+	// RoundTowardZero truncates.
+	RoundTowardZero
+)
+
+// RoundTo rounds a to a multiple of unit using mode. For example
+// RoundTo(MilliBTC, RoundHalfUp) on 1.2345 BTC returns 1.235 BTC.
+// If unit is not positive, a is returned unchanged. Like ordinary
+// multiplication the result wraps if rounding away from zero moves
+// it past the range of Amount; use RoundToChecked to detect that.
+func (a Amount) RoundTo(unit Amount, mode RoundingMode) Amount {
+	if unit <= 0 {
+		return a
+	}
+
+	return divRound(a, unit, mode) * unit
+}
+
+// RoundToChecked works like RoundTo, but returns ErrOverflow if the
+// rounded value does not fit in an Amount.
+func (a Amount) RoundToChecked(unit Amount, mode RoundingMode) (Amount, error) {
+	if unit <= 0 {
+		return a, nil
+	}
+
+	return divRound(a, unit, mode).MulChecked(int64(unit))
+}
+
+// divRound returns a/b rounded using mode. b must be positive.
+func divRound(a, b Amount, mode RoundingMode) Amount {
+	return roundQuotient(a/b, a%b, b, mode)
+}
+
+// roundQuotient rounds the result of a truncated division with
+// quotient q and remainder r by the positive divisor b.
+func roundQuotient(q, r, b Amount, mode RoundingMode) Amount {
+	if r == 0 {
+		return q
+	}
+
+	away := false
+
+	switch mode {
+	case RoundFloor:
+		away = r < 0
+
+	case RoundCeil:
+		away = r > 0
+
+	case RoundTowardZero:
+
+	default:
+		// Compare |r| with b-|r| rather than 2*|r| with b to avoid
+		// overflowing.
+		rest := r.Abs()
+		diff := rest - (b - rest)
+
+		away = diff > 0 || (diff == 0 && (mode == RoundHalfUp || q%2 != 0))
+	}
+
+	if !away {
+		return q
+	}
+
+	if r > 0 {
+		return q + 1
+	}
+
+	return q - 1
+}
+
+// bigDivRound returns a/b rounded using mode. b must be positive.
+func bigDivRound(a, b *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(a, b, new(big.Int))
+
+	if r.Sign() == 0 {
+		return q
+	}
+
+	away := false
+
+	switch mode {
+	case RoundFloor:
+		away = r.Sign() < 0
+
+	case RoundCeil:
+		away = r.Sign() > 0
+
+	case RoundTowardZero:
+
+	default:
+		twice := new(big.Int).Abs(r)
+		twice.Lsh(twice, 1)
+		cmp := twice.Cmp(b)
+
+		away = cmp > 0 || (cmp == 0 && (mode == RoundHalfUp || q.Bit(0) != 0))
+	}
+
+	if !away {
+		return q
+	}
+
+	return q.Add(q, big.NewInt(int64(r.Sign())))
+}
@@ -0,0 +1,107 @@
+package bitcoin
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestRoundTo(t *testing.T) {
+	cases := []struct {
+		in       Amount
+		unit     Amount
+		mode     RoundingMode
+		expected Amount
+	}{
+		{1234500, MilliBTC, RoundHalfUp, 1200000},
+		{1250000, MilliBTC, RoundHalfUp, 1300000},
+		{123450000, MilliBTC, RoundHalfUp, 123500000},
+		{1234500, MicroBTC, RoundHalfUp, 1234500},
+		{1234550, MicroBTC, RoundHalfUp, 1234600},
+		{1234650, MicroBTC, RoundHalfUp, 1234700},
+		{-1234550, MicroBTC, RoundHalfUp, -1234600},
+		{1234549, MicroBTC, RoundHalfUp, 1234500},
+
+		{1234550, MicroBTC, RoundHalfEven, 1234600},
+		{1234650, MicroBTC, RoundHalfEven, 1234600},
+		{-1234550, MicroBTC, RoundHalfEven, -1234600},
+		{-1234650, MicroBTC, RoundHalfEven, -1234600},
+		{1234651, MicroBTC, RoundHalfEven, 1234700},
+
+		{1234599, MicroBTC, RoundFloor, 1234500},
+		{-1234501, MicroBTC, RoundFloor, -1234600},
+		{-1234500, MicroBTC, RoundFloor, -1234500},
+
+		{1234501, MicroBTC, RoundCeil, 1234600},
+		{-1234599, MicroBTC, RoundCeil, -1234500},
+		{1234500, MicroBTC, RoundCeil, 1234500},
+
+		{1234599, MicroBTC, RoundTowardZero, 1234500},
+		{-1234599, MicroBTC, RoundTowardZero, -1234500},
+
+		{BTC + 500*MilliBTC, BTC, RoundHalfUp, 2 * BTC},
+		{BTC + 500*MilliBTC, BTC, RoundHalfEven, 2 * BTC},
+		{2*BTC + 500*MilliBTC, BTC, RoundHalfEven, 2 * BTC},
+		{BTC + 500*MilliBTC, BTC, RoundTowardZero, BTC},
+
+		{5, 0, RoundHalfUp, 5},
+		{5, -10, RoundHalfUp, 5},
+		{0, BTC, RoundCeil, 0},
+
+		{math.MaxInt64, BTC, RoundFloor, 92233720368 * BTC},
+		{math.MinInt64, BTC, RoundCeil, -92233720368 * BTC},
+		{math.MaxInt64, Satoshi, RoundCeil, math.MaxInt64},
+	}
+
+	for _, c := range cases {
+		result := c.in.RoundTo(c.unit, c.mode)
+
+		if result != c.expected {
+			t.Errorf("%d.RoundTo(%d, %d) -> %d, %d expected", c.in, c.unit, c.mode, result, c.expected)
+		}
+	}
+}
+
+func TestRoundToChecked(t *testing.T) {
+	cases := []struct {
+		in       Amount
+		unit     Amount
+		mode     RoundingMode
+		expected Amount
+		err      error
+	}{
+		{1234550, MicroBTC, RoundHalfUp, 1234600, nil},
+		{math.MaxInt64, BTC, RoundFloor, 92233720368 * BTC, nil},
+		{math.MaxInt64, BTC, RoundCeil, 0, ErrOverflow},
+		{math.MaxInt64, BTC, RoundHalfUp, 0, ErrOverflow},
+		{math.MinInt64, BTC, RoundCeil, -92233720368 * BTC, nil},
+		{math.MinInt64, BTC, RoundFloor, 0, ErrOverflow},
+		{math.MinInt64, Satoshi, RoundFloor, math.MinInt64, nil},
+		{5, 0, RoundHalfUp, 5, nil},
+	}
+
+	for _, c := range cases {
+		result, err := c.in.RoundToChecked(c.unit, c.mode)
+
+		if result != c.expected || err != c.err {
+			t.Errorf("%d.RoundToChecked(%d, %d) -> %d, %v, %d, %v expected", c.in, c.unit, c.mode, result, err, c.expected, c.err)
+		}
+	}
+}
+
+func TestBigDivRound(t *testing.T) {
+	modes := []RoundingMode{RoundHalfUp, RoundHalfEven, RoundFloor, RoundCeil, RoundTowardZero}
+
+	for a := Amount(-30); a <= 30; a++ {
+		for b := Amount(1); b <= 7; b++ {
+			for _, mode := range modes {
+				expected := divRound(a, b, mode)
+				result := bigDivRound(big.NewInt(int64(a)), big.NewInt(int64(b)), mode)
+
+				if result.Int64() != int64(expected) {
+					t.Errorf("bigDivRound(%d, %d, %d) -> %d, %d expected", a, b, mode, result.Int64(), expected)
+				}
+			}
+		}
+	}
+}
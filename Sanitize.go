@@ -0,0 +1,215 @@
+package bitcoin
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Warning describes something SanitizeInput changed or found
+// questionable in user input.
+type Warning int
+
+const (
+	// WarningSymbol means a currency symbol such as "₿" or "BTC"
+	// was removed.
+	WarningSymbol Warning = iota + 1
+
+	// WarningDigits means non-ASCII digits were converted to their
+	// ASCII equivalents.
+	WarningDigits
+
+	// WarningGrouping means grouping separators were removed. For
+	// example "1,234.5" became "1234.5".
+	WarningGrouping
+
+	// WarningAmbiguous means the input had a single separator
+	// followed by exactly three digits. "1,500" was read as 1.5, but
+	// the user may have meant 1500.
+	WarningAmbiguous
+)
+
+// String implements fmt.Stringer.
+func (w Warning) String() string {
+	switch w {
+	case WarningSymbol:
+		return "currency symbol removed"
+
+	case WarningDigits:
+		return "non-ASCII digits normalized"
+
+	case WarningGrouping:
+		return "grouping separators removed"
+
+	case WarningAmbiguous:
+		return "ambiguous decimal separator"
+
+	default:
+		return "unknown warning"
+	}
+}
+
+// currencySymbols are stripped by SanitizeInput. They all denote
+// whole bitcoins, so removing them does not change the scale. A
+// symbol preceded by a letter, like the "BTC" of "mBTC" or "µBTC",
+// is part of another unit and is left in place.
+var currencySymbols = []string{"BTC", "XBT", "₿", "Ƀ"}
+
+// SanitizeInput cleans up an amount typed by a user before it is
+// handed to Parse. It strips currency symbols and whitespace,
+// normalizes unicode digits and minus signs, and resolves comma and
+// point usage so the result uses "." as the decimal mark.
+// Anything it does not understand is left in place for Parse to
+// reject. The returned warnings should be shown to the user or
+// logged; a clean string with no warnings was already well-formed.
+func SanitizeInput(s string) (string, []Warning) {
+	var warnings []Warning
+
+	warn := func(w Warning) {
+		for _, existing := range warnings {
+			if existing == w {
+				return
+			}
+		}
+
+		warnings = append(warnings, w)
+	}
+
+	runes := []rune(s)
+	clean := make([]rune, 0, len(runes))
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		if n := symbolAt(runes, i); n > 0 {
+			warn(WarningSymbol)
+			i += n - 1
+
+			continue
+		}
+
+		switch {
+		case r >= '0' && r <= '9', r == '.', r == ',', r == '+', r == '-':
+			clean = append(clean, r)
+
+		case r == '−':
+			clean = append(clean, '-')
+
+		case r == '٫':
+			clean = append(clean, '.')
+
+		case r == '٬':
+			clean = append(clean, ',')
+
+		case unicode.IsDigit(r):
+			clean = append(clean, '0'+digitValue(r))
+			warn(WarningDigits)
+
+		case unicode.IsSpace(r):
+			// Spaces between digits group them, like "1 234 567".
+			afterDigit := len(clean) > 0 && unicode.IsDigit(clean[len(clean)-1])
+
+			if afterDigit && digitFollows(runes[i+1:]) {
+				warn(WarningGrouping)
+			}
+
+		case r == '\'', r == '’', r == '_':
+			warn(WarningGrouping)
+
+		default:
+			clean = append(clean, r)
+		}
+	}
+
+	out := string(clean)
+
+	commas := strings.Count(out, ",")
+	points := strings.Count(out, ".")
+
+	switch {
+	case commas > 0 && points > 0:
+		// The last separator is the decimal mark.
+		if strings.LastIndexByte(out, ',') > strings.LastIndexByte(out, '.') {
+			out = strings.Replace(out, ".", "", -1)
+			out = strings.Replace(out, ",", ".", -1)
+		} else {
+			out = strings.Replace(out, ",", "", -1)
+		}
+
+		warn(WarningGrouping)
+
+	case commas > 1:
+		out = strings.Replace(out, ",", "", -1)
+		warn(WarningGrouping)
+
+	case points > 1:
+		out = strings.Replace(out, ".", "", -1)
+		warn(WarningGrouping)
+
+	case commas == 1, points == 1:
+		out = strings.Replace(out, ",", ".", 1)
+
+		if ambiguous(out) {
+			warn(WarningAmbiguous)
+		}
+	}
+
+	return out, warnings
+}
+
+// ambiguous reports whether the single decimal point in s could
+// also have been meant as a grouping separator. That is the case
+// for "1.500" but not for "0.500" or "1234.500".
+func ambiguous(s string) bool {
+	point := strings.IndexByte(s, '.')
+	whole := strings.TrimLeft(s[:point], "+-")
+
+	if len(s)-point-1 != 3 || len(whole) == 0 || len(whole) > 3 {
+		return false
+	}
+
+	return strings.Trim(whole, "0") != ""
+}
+
+// symbolAt returns the number of runes occupied by a currency
+// symbol at position i of in, or 0 if there is none.
+func symbolAt(in []rune, i int) int {
+	if i > 0 && unicode.IsLetter(in[i-1]) {
+		return 0
+	}
+
+	in = in[i:]
+
+	for _, symbol := range currencySymbols {
+		s := []rune(symbol)
+
+		if len(in) >= len(s) && strings.EqualFold(string(in[:len(s)]), symbol) {
+			return len(s)
+		}
+	}
+
+	return 0
+}
+
+// digitFollows reports whether the first rune in in that is not a
+// space is a digit.
+func digitFollows(in []rune) bool {
+	for _, r := range in {
+		if !unicode.IsSpace(r) {
+			return unicode.IsDigit(r)
+		}
+	}
+
+	return false
+}
+
+// digitValue returns the numeric value of a unicode decimal digit.
+// Unicode assigns decimal digits in contiguous runs starting at
+// zero, so the value is the distance from the start of the run.
+func digitValue(r rune) rune {
+	start := r
+	for unicode.IsDigit(start - 1) {
+		start--
+	}
+
+	return (r - start) % 10
+}
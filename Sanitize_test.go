@@ -0,0 +1,53 @@
+package bitcoin
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSanitizeInput(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected string
+		warnings []Warning
+	}{
+		{"", "", nil},
+		{"0.5", "0.5", nil},
+		{" 0.5 ", "0.5", nil},
+		{"0,5", "0.5", nil},
+		{"₿0.5", "0.5", []Warning{WarningSymbol}},
+		{"0.5 BTC", "0.5", []Warning{WarningSymbol}},
+		{"0.5 btc", "0.5", []Warning{WarningSymbol}},
+		{"XBT 2", "2", []Warning{WarningSymbol}},
+		{"−0.5", "-0.5", nil},
+		{"٠٫٥", "0.5", []Warning{WarningDigits}},
+		{"１.５", "1.5", []Warning{WarningDigits}},
+		{"१२", "12", []Warning{WarningDigits}},
+		{"1,234.5", "1234.5", []Warning{WarningGrouping}},
+		{"1.234,5", "1234.5", []Warning{WarningGrouping}},
+		{"1,234,567", "1234567", []Warning{WarningGrouping}},
+		{"1.234.567", "1234567", []Warning{WarningGrouping}},
+		{"1 234 567,5", "1234567.5", []Warning{WarningGrouping}},
+		{"1\u00a0234,5", "1234.5", []Warning{WarningGrouping}},
+		{"- 1.5", "-1.5", nil},
+		{"1'234.5", "1234.5", []Warning{WarningGrouping}},
+		{"1,500", "1.500", []Warning{WarningAmbiguous}},
+		{"1.500", "1.500", []Warning{WarningAmbiguous}},
+		{"-1.500", "-1.500", []Warning{WarningAmbiguous}},
+		{"0.500", "0.500", nil},
+		{".500", ".500", nil},
+		{"1234.500", "1234.500", nil},
+		{"1.5 mBTC", "1.5mBTC", nil},
+		{"1.5 µBTC", "1.5µBTC", nil},
+		{"0.5BTC", "0.5", []Warning{WarningSymbol}},
+		{"12 sats", "12sats", nil},
+	}
+
+	for _, c := range cases {
+		result, warnings := SanitizeInput(c.in)
+
+		if result != c.expected || !reflect.DeepEqual(warnings, c.warnings) {
+			t.Errorf("SanitizeInput('%s') -> '%s' %v, '%s' %v expected", c.in, result, warnings, c.expected, c.warnings)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package bitcoin
+
+// HalvingInterval is the number of blocks between subsidy halvings.
+const HalvingInterval = 210000
+
+// initialSubsidy is the subsidy of the first blocks.
+const initialSubsidy = 50 * BTC
+
+// BlockSubsidy returns the newly minted amount in the block at
+// height, following the consensus halving schedule.
+func BlockSubsidy(height int64) Amount {
+	if height < 0 {
+		return 0
+	}
+
+	halvings := height / HalvingInterval
+
+	// Shifting by 64 or more is undefined in consensus code, so
+	// Bitcoin Core forces the subsidy to zero.
+	if halvings >= 64 {
+		return 0
+	}
+
+	return initialSubsidy >> uint(halvings)
+}
+
+// HalvingHeight returns the height of the first block after the
+// n'th halving. HalvingHeight(4) returns 840000.
+func HalvingHeight(n int64) int64 {
+	return n * HalvingInterval
+}
+
+// TotalSupplyAt returns the total subsidy of all blocks up to and
+// including height. The genesis block is included even though its
+// output cannot be spent, so the total converges on AllBTC.
+func TotalSupplyAt(height int64) Amount {
+	if height < 0 {
+		return 0
+	}
+
+	supply := Amount(0)
+
+	for era := int64(0); era < 64; era++ {
+		start := HalvingHeight(era)
+		if start > height {
+			break
+		}
+
+		blocks := int64(HalvingInterval)
+		if last := height - start; last < blocks-1 {
+			blocks = last + 1
+		}
+
+		supply += Amount(blocks) * BlockSubsidy(start)
+	}
+
+	return supply
+}
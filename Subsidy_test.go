@@ -0,0 +1,76 @@
+package bitcoin
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBlockSubsidy(t *testing.T) {
+	cases := []struct {
+		height   int64
+		expected Amount
+	}{
+		{-1, 0},
+		{0, 50 * BTC},
+		{209999, 50 * BTC},
+		{210000, 25 * BTC},
+		{420000, 12*BTC + 500*MilliBTC},
+		{630000, 6*BTC + 250*MilliBTC},
+		{840000, 3*BTC + 125*MilliBTC},
+		{HalvingHeight(32), 1},
+		{HalvingHeight(33), 0},
+		{HalvingHeight(64), 0},
+		{math.MaxInt64, 0},
+	}
+
+	for _, c := range cases {
+		result := BlockSubsidy(c.height)
+
+		if result != c.expected {
+			t.Errorf("BlockSubsidy(%d) -> %d, %d expected", c.height, result, c.expected)
+		}
+	}
+}
+
+func TestHalvingHeight(t *testing.T) {
+	cases := []struct {
+		n        int64
+		expected int64
+	}{
+		{0, 0},
+		{1, 210000},
+		{4, 840000},
+	}
+
+	for _, c := range cases {
+		result := HalvingHeight(c.n)
+
+		if result != c.expected {
+			t.Errorf("HalvingHeight(%d) -> %d, %d expected", c.n, result, c.expected)
+		}
+	}
+}
+
+func TestTotalSupplyAt(t *testing.T) {
+	cases := []struct {
+		height   int64
+		expected Amount
+	}{
+		{-1, 0},
+		{0, 50 * BTC},
+		{1, 100 * BTC},
+		{209999, 10500000 * BTC},
+		{210000, 10500025 * BTC},
+		{839999, 19687500 * BTC},
+		{HalvingHeight(33), AllBTC},
+		{math.MaxInt64, AllBTC},
+	}
+
+	for _, c := range cases {
+		result := TotalSupplyAt(c.height)
+
+		if result != c.expected {
+			t.Errorf("TotalSupplyAt(%d) -> %d, %d expected", c.height, result, c.expected)
+		}
+	}
+}
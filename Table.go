@@ -0,0 +1,220 @@
+package bitcoin
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Table renders amounts as a plain text table for terminals, like
+// balance or UTXO listings. Amounts are aligned on the decimal
+// point, and each column is shown in a single unit:
+//
+//	Account       Balance    Pending
+//	savings   1.5     BTC  -250 mBTC
+//	spending  0.00021 BTC     0 mBTC
+type Table struct {
+	// Label is the header of the first column, which holds the row
+	// labels.
+	Label string
+
+	// Columns describes the amount columns.
+	Columns []Column
+
+	// Rows holds the data. Missing values are left blank.
+	Rows []TableRow
+
+	// Color shows negative amounts in red using ANSI escape codes.
+	Color bool
+}
+
+// Column is an amount column in a Table.
+type Column struct {
+	// Header is shown right-aligned above the column.
+	Header string
+
+	// Formatter formats the amounts of the column. Its Unit
+	// normalizes the column to one unit.
+	Formatter Formatter
+}
+
+// TableRow is a row of a Table.
+type TableRow struct {
+	// Label is shown in the first column.
+	Label string
+
+	// Values holds one amount per column.
+	Values []Amount
+}
+
+// cell is a formatted amount split for alignment.
+type cell struct {
+	whole    string
+	frac     string
+	suffix   string
+	negative bool
+}
+
+const (
+	ansiRed   = "\x1b[31m"
+	ansiReset = "\x1b[0m"
+)
+
+// WriteTo implements io.WriterTo, writing the table to w.
+func (t Table) WriteTo(w io.Writer) (int64, error) {
+	cells := make([][]cell, len(t.Rows))
+
+	labelWidth := textWidth(t.Label)
+	wholeWidth := make([]int, len(t.Columns))
+	fracWidth := make([]int, len(t.Columns))
+	suffixWidth := make([]int, len(t.Columns))
+
+	for i, row := range t.Rows {
+		labelWidth = maxInt(labelWidth, textWidth(row.Label))
+
+		cells[i] = make([]cell, len(t.Columns))
+
+		for j, column := range t.Columns {
+			if j >= len(row.Values) {
+				continue
+			}
+
+			c := splitCell(column.Formatter, row.Values[j])
+			cells[i][j] = c
+
+			wholeWidth[j] = maxInt(wholeWidth[j], textWidth(c.whole))
+			fracWidth[j] = maxInt(fracWidth[j], textWidth(c.frac))
+			suffixWidth[j] = maxInt(suffixWidth[j], textWidth(c.suffix))
+		}
+	}
+
+	var buf bytes.Buffer
+
+	// Headers are right-aligned, widening the column if needed.
+	columnWidth := make([]int, len(t.Columns))
+
+	buf.WriteString(padText(t.Label, labelWidth, false))
+
+	for j, column := range t.Columns {
+		columnWidth[j] = maxInt(textWidth(column.Header), wholeWidth[j]+fracWidth[j]+suffixWidth[j])
+
+		buf.WriteString("  ")
+		buf.WriteString(padText(column.Header, columnWidth[j], true))
+	}
+
+	writeLine(&buf)
+
+	for i, row := range t.Rows {
+		buf.WriteString(padText(row.Label, labelWidth, false))
+
+		for j := range t.Columns {
+			buf.WriteString("  ")
+
+			if j >= len(row.Values) {
+				buf.WriteString(strings.Repeat(" ", columnWidth[j]))
+
+				continue
+			}
+
+			c := cells[i][j]
+
+			s := padText(c.whole, columnWidth[j]-fracWidth[j]-suffixWidth[j], true) +
+				padText(c.frac, fracWidth[j], false) +
+				padText(c.suffix, suffixWidth[j], false)
+
+			if t.Color && c.negative {
+				// Keep the padding outside the colored text.
+				lead := len(s) - len(strings.TrimLeft(s, " "))
+				s = s[:lead] + ansiRed + s[lead:] + ansiReset
+			}
+
+			buf.WriteString(s)
+		}
+
+		writeLine(&buf)
+	}
+
+	return buf.WriteTo(w)
+}
+
+// String returns the table as text.
+func (t Table) String() string {
+	var b strings.Builder
+
+	_, _ = t.WriteTo(&b)
+
+	return b.String()
+}
+
+// splitCell formats a with f and splits the result into the whole
+// part, the fraction with its decimal mark and the suffix.
+func splitCell(f Formatter, a Amount) cell {
+	s := f.Format(a)
+
+	suffix := f.Suffix
+	if strings.HasSuffix(s, ")") {
+		suffix += ")"
+	}
+
+	s = strings.TrimSuffix(s, suffix)
+
+	decimal := f.Decimal
+	if decimal == "" {
+		decimal = "."
+	}
+
+	c := cell{whole: s, suffix: suffix, negative: a < 0}
+
+	// Satoshis have no fraction, and the grouping separator may be
+	// the same as the default decimal mark.
+	if f.Unit == Satoshi && f.MinDecimals == 0 {
+		return c
+	}
+
+	if i := strings.Index(s, decimal); i >= 0 {
+		c.whole = s[:i]
+		c.frac = s[i:]
+	}
+
+	return c
+}
+
+// writeLine ends a line, dropping trailing padding.
+func writeLine(buf *bytes.Buffer) {
+	line := bytes.LastIndexByte(buf.Bytes(), '\n') + 1
+	trimmed := bytes.TrimRight(buf.Bytes()[line:], " ")
+
+	buf.Truncate(line + len(trimmed))
+	buf.WriteByte('\n')
+}
+
+// padText pads s with spaces to w runes, on the left if right is
+// set.
+func padText(s string, w int, right bool) string {
+	n := w - textWidth(s)
+	if n <= 0 {
+		return s
+	}
+
+	if right {
+		return strings.Repeat(" ", n) + s
+	}
+
+	return s + strings.Repeat(" ", n)
+}
+
+// textWidth returns the width of s in a terminal, assuming one column
+// per rune.
+func textWidth(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+// maxInt returns the larger of a and b.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
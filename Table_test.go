@@ -0,0 +1,70 @@
+package bitcoin
+
+import (
+	"testing"
+)
+
+func TestTable(t *testing.T) {
+	btc := Column{"Balance", Formatter{Suffix: " BTC"}}
+	mbtc := Column{"Pending", Formatter{Unit: MilliBTC, Suffix: " mBTC"}}
+
+	cases := []struct {
+		in       Table
+		expected string
+	}{
+		{
+			Table{
+				Label:   "Account",
+				Columns: []Column{btc, mbtc},
+				Rows: []TableRow{
+					{"savings", []Amount{150000000, -25000000}},
+					{"spending", []Amount{21000, 0}},
+				},
+			},
+			"Account       Balance    Pending\n" +
+				"savings   1.5     BTC  -250 mBTC\n" +
+				"spending  0.00021 BTC     0 mBTC\n",
+		},
+		{
+			Table{
+				Columns: []Column{btc, mbtc},
+				Rows: []TableRow{
+					{"a", []Amount{-BTC}},
+					{"b", nil},
+				},
+				Color: true,
+			},
+			"   Balance  Pending\n" +
+				"a   \x1b[31m-1 BTC\x1b[0m\n" +
+				"b\n",
+		},
+		{
+			Table{
+				Label: "UTXO",
+				Columns: []Column{{"Value", Formatter{Unit: Satoshi, Grouping: ".", Suffix: " sats"}}, {
+					"Net",
+					Formatter{Grouping: " ", Decimal: ",", MaxDecimals: 2, Suffix: " BTC", Parentheses: true},
+				}},
+				Rows: []TableRow{
+					{"abcd:0", []Amount{1234567, -1234 * BTC}},
+					{"ef01:1", []Amount{546, 10 * MilliBTC}},
+				},
+			},
+			"UTXO             Value             Net\n" +
+				"abcd:0  1.234.567 sats  (1 234    BTC)\n" +
+				"ef01:1        546 sats       0,01 BTC\n",
+		},
+		{
+			Table{Label: "Empty", Columns: []Column{btc}},
+			"Empty  Balance\n",
+		},
+	}
+
+	for _, c := range cases {
+		result := c.in.String()
+
+		if result != c.expected {
+			t.Errorf("String() ->\n%q\n%q expected", result, c.expected)
+		}
+	}
+}
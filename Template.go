@@ -0,0 +1,51 @@
+package bitcoin
+
+import (
+	"strings"
+)
+
+// TemplateFuncs returns template functions for formatting amounts:
+//
+//	btc       1.5 BTC
+//	mbtc      1500 mBTC
+//	sats      150000000 sats
+//	satcomma  1.50,000,000 BTC
+//	fiat      37500.00 EUR, taking a Price and an Amount
+//
+// The functions take an Amount, so a template can write
+// {{ .Balance | btc }} or {{ .Balance | fiat .Price }}. The map type
+// is unnamed, so it can be passed to Funcs of both html/template and
+// text/template, and this package does not import either.
+func TemplateFuncs() map[string]interface{} {
+	return map[string]interface{}{
+		"btc": func(a Amount) string {
+			return a.Format(BTC) + " BTC"
+		},
+		"mbtc": func(a Amount) string {
+			return a.Format(MilliBTC) + " mBTC"
+		},
+		"sats": func(a Amount) string {
+			return a.Format(Satoshi) + " sats"
+		},
+		"satcomma": func(a Amount) string {
+			return satcomma(a) + " BTC"
+		},
+		"fiat": func(p Price, a Amount) (string, error) {
+			f, err := a.FiatValue(p, RoundHalfUp)
+			if err != nil {
+				return "", err
+			}
+
+			return f.String(), nil
+		},
+	}
+}
+
+// satcomma formats a in BTC with all eight decimals, grouped with
+// commas as 0.00,000,000 to make the satoshis easy to read.
+func satcomma(a Amount) string {
+	left, right := a.SplitString(BTC)
+	right += strings.Repeat("0", 8-len(right))
+
+	return left + "." + right[:2] + "," + right[2:5] + "," + right[5:]
+}
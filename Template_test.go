@@ -0,0 +1,56 @@
+package bitcoin
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"math"
+	"testing"
+	"text/template"
+)
+
+func TestSatcomma(t *testing.T) {
+	cases := []struct {
+		in       Amount
+		expected string
+	}{
+		{0, "0.00,000,000"},
+		{Satoshi, "0.00,000,001"},
+		{12345678, "0.12,345,678"},
+		{1500 * MilliBTC, "1.50,000,000"},
+		{-Satoshi, "-0.00,000,001"},
+		{math.MinInt64, "-92233720368.54,775,808"},
+	}
+
+	for _, c := range cases {
+		result := satcomma(c.in)
+
+		if result != c.expected {
+			t.Errorf("satcomma(%d) -> '%s', '%s' expected", c.in, result, c.expected)
+		}
+	}
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	const text = `{{ .Balance | btc }}|{{ .Balance | mbtc }}|{{ .Balance | sats }}|{{ .Balance | satcomma }}|{{ .Balance | fiat .Price }}`
+
+	data := struct {
+		Balance Amount
+		Price   Price
+	}{1500 * MilliBTC, Price{"EUR", 2500000, 2}}
+
+	expected := "1.5 BTC|1500 mBTC|150000000 sats|1.50,000,000 BTC|37500.00 EUR"
+
+	var out bytes.Buffer
+
+	err := template.Must(template.New("").Funcs(TemplateFuncs()).Parse(text)).Execute(&out, data)
+	if out.String() != expected || err != nil {
+		t.Errorf("text/template Execute() -> '%s', %v, '%s' expected", out.String(), err, expected)
+	}
+
+	out.Reset()
+
+	err = htmltemplate.Must(htmltemplate.New("").Funcs(TemplateFuncs()).Parse(text)).Execute(&out, data)
+	if out.String() != expected || err != nil {
+		t.Errorf("html/template Execute() -> '%s', %v, '%s' expected", out.String(), err, expected)
+	}
+}